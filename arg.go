@@ -5,21 +5,89 @@ import (
 	"sync"
 )
 
-const (
-	// argPoolAlloc specifies the allocation size of *Args returned from argPool.
-	argPoolAlloc = 5
+var (
+	// ArgPoolWidth specifies the allocation size of the Values and Pointers slices in *Args
+	// newly created by the argument pool.
+	//
+	// Functions with more arguments than ArgPoolWidth cause the slices to be reallocated.
+	// ArgPoolWidth should be set before any calls to Args() and not modified afterwards.
+	ArgPoolWidth = 5
+
+	// ArgPoolMaxWidth specifies the largest capacity of Values that is returned to the
+	// argument pool; an *Args that has grown beyond ArgPoolMaxWidth is discarded instead.
+	// The zero value means 8 times ArgPoolWidth, so raising ArgPoolWidth also raises the
+	// limit unless ArgPoolMaxWidth is set explicitly.
+	//
+	// Discarding oversized *Args prevents an occasional call to a function with many arguments
+	// from leaving large backing arrays in the pool forever.  ArgPoolMaxWidth should be set
+	// before any calls to Args() and not modified afterwards.
+	ArgPoolMaxWidth = 0
 )
 
+// argPoolMaxWidth returns the effective value of ArgPoolMaxWidth.
+func argPoolMaxWidth() int {
+	if ArgPoolMaxWidth == 0 {
+		return 8 * ArgPoolWidth
+	}
+	return ArgPoolMaxWidth
+}
+
 // argPool is a sync.Pool for *Args values.
 var argPool = sync.Pool{
 	New: func() interface{} {
-		return &Args{
-			Values:   make([]reflect.Value, argPoolAlloc),
-			Pointers: make([]interface{}, argPoolAlloc),
-		}
+		return allocArgs(ArgPoolWidth)
 	},
 }
 
+// allocArgs allocates an *Args with Values and Pointers of length N.
+func allocArgs(N int) *Args {
+	return &Args{
+		Values:   make([]reflect.Value, N),
+		Pointers: make([]interface{}, N),
+	}
+}
+
+// getArgs returns an *Args from the argument pool with Values and Pointers of length N.
+func getArgs(N int) *Args {
+	return sized(argPool.Get().(*Args), N)
+}
+
+// sized prepares args, which may be reused from a pool, to hold N arguments and returns it.
+func sized(args *Args, N int) *Args {
+	args.Reset(N)
+	args.Values, args.Pointers = args.Values[:N], args.Pointers[:N]
+	return args
+}
+
+// putArgs clears the elements of args and returns it to the argument pool unless its
+// capacity exceeds ArgPoolMaxWidth.  *Args from the private pool of a Func, see Func.SetHot,
+// are sized for the function and always returned to it.  Arguments drawn from a pool
+// registered with Func.SetArgPool are first given to the pool's put function.
+func putArgs(args *Args) {
+	for k, arg := range args.pooled {
+		if arg.pool.put != nil && args.Values[arg.N].IsValid() {
+			arg.pool.put(args.Values[arg.N])
+		}
+		args.pooled[k] = Arg{}
+	}
+	args.pooled = args.pooled[:0]
+	for k := range args.Values {
+		args.Values[k] = zeroReflectValue
+	}
+	for k := range args.Pointers {
+		args.Pointers[k] = nil
+	}
+	if args.owned {
+		return
+	} else if args.pool != nil {
+		args.pool.Put(args)
+		return
+	} else if cap(args.Values) > argPoolMaxWidth() {
+		return
+	}
+	argPool.Put(args)
+}
+
 // Arg describes a function or method argument by its type T, its index N, and if it can be
 // known or calculated in advance its value V.
 type Arg struct {
@@ -27,8 +95,18 @@ type Arg struct {
 	N int
 	// Argument type.
 	T reflect.Type
-	// If reusable then the reusable reflect.Value
+	// If reusable then the reusable reflect.Value; for arguments in InCreate the default
+	// value set by SetDefault, if any.
 	V reflect.Value
+
+	// pool is set by SetArgPool.
+	pool *typePool
+}
+
+// typePool is an application pool of values of a single type; see Func.SetArgPool.
+type typePool struct {
+	get func() reflect.Value
+	put func(reflect.Value)
 }
 
 // Args is created by calling Args() on a Func or a Method.
@@ -43,11 +121,124 @@ type Arg struct {
 type Args struct {
 	Values   []reflect.Value
 	Pointers []interface{}
+
+	// pooled are the arguments whose values were drawn from a typePool.
+	pooled []Arg
+	// owned is true if the *Args belongs to a single-threaded Func and not the argument pool.
+	owned bool
+	// pool is the private pool of a Func the *Args belongs to; see Func.SetHot.
+	pool *sync.Pool
+}
+
+// NewArgs returns an *Args whose Values are the given values and whose Pointers are nil; it is
+// intended for callers that already have the arguments as reflect.Value, such as when
+// replaying a recorded call.
+//
+// The returned *Args is taken from the argument pool and values is copied into it; values is
+// not retained.  Like the *Args returned by Args() it is returned to the argument pool by
+// Call() and must not be used afterwards.
+func NewArgs(values ...reflect.Value) *Args {
+	rv := getArgs(len(values))
+	copy(rv.Values, values)
+	return rv
+}
+
+// Release returns args to the argument pool without calling the function; the elements of
+// Values and Pointers are cleared first so the pool does not keep them alive.  Use Release
+// when args obtained from Args() will not be passed to Call(), such as when decoding fails.
+//
+// args must not be used after Release and must not be released twice.  Call() releases its
+// arguments; a deferred Release must therefore be skipped once Call() is reached:
+//
+//	args := f.Args()
+//	called := false
+//	defer func() {
+//		if !called {
+//			args.Release()
+//		}
+//	}()
+//	if err := args.UnmarshalJSONInto(0, body); err != nil {
+//		return err
+//	}
+//	called = true
+//	f.Call(args)
+func (args *Args) Release() {
+	putArgs(args)
+}
+
+// releaseOnPanic releases args and continues panicking if a panic is in progress; it must be
+// called directly by a deferred statement.
+func releaseOnPanic(args *Args) {
+	if r := recover(); r != nil {
+		putArgs(args)
+		panic(r)
+	}
+}
+
+// decodeArgs calls decode and releases args if decode returns an error or panics; a panic is
+// propagated after args is released.  It is used by helpers that create, decode, and call.
+func decodeArgs(args *Args, decode func() error) error {
+	defer releaseOnPanic(args)
+	err := decode()
+	if err != nil {
+		putArgs(args)
+	}
+	return err
 }
 
 // Reset ensures the Values and Pointers slices have enough capacity for N elements.
 func (args *Args) Reset(N int) {
-	if N > cap(args.Values) {
+	if N > cap(args.Values) || N > cap(args.Pointers) {
 		args.Values, args.Pointers = make([]reflect.Value, N), make([]interface{}, N)
 	}
 }
+
+// ConcreteType returns the dynamic type of the value currently in Values[index]; for arguments
+// of interface type this is the implementation that will receive the call.  Non-interface
+// arguments return their own type.
+//
+// ok is false if index is out of range or the value is invalid or nil.
+func (args *Args) ConcreteType(index int) (T reflect.Type, ok bool) {
+	if index < 0 || index >= len(args.Values) {
+		return nil, false
+	}
+	V := args.Values[index]
+	if !V.IsValid() {
+		return nil, false
+	} else if V.Kind() == reflect.Interface {
+		V = V.Elem()
+		if !V.IsValid() {
+			return nil, false
+		}
+	}
+	if nilable(V.Kind()) && V.IsNil() {
+		return nil, false
+	}
+	return V.Type(), true
+}
+
+// Clone returns a copy of args taken from the argument pool.
+//
+// Values drawn from a pool registered with Func.SetArgPool are shared with the copy but are
+// returned to their pool only when args is released.
+//
+// Arguments with a pointer are copied into newly created values with new Pointers entries;
+// the copy is made by assignment so slices, maps, and pointers within an argument share their
+// underlying data.  Arguments without a pointer, such as values from InCache, are shared as-is.
+//
+// Clone allows the same arguments to be passed to Call more than once since Call returns its
+// arguments to the pool.
+func (args *Args) Clone() *Args {
+	var V reflect.Value
+	rv := getArgs(len(args.Values))
+	for k, value := range args.Values {
+		if k >= len(args.Pointers) || args.Pointers[k] == nil {
+			rv.Values[k] = value
+			continue
+		}
+		V = reflect.New(value.Type())
+		V.Elem().Set(value)
+		rv.Values[k], rv.Pointers[k] = V.Elem(), V.Interface()
+	}
+	return rv
+}
@@ -0,0 +1,71 @@
+package call
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgs_PutArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	small, big := &Args{}, &Args{}
+	small.Reset(ArgPoolWidth)
+	big.Reset(argPoolMaxWidth() + 1)
+	for _, args := range []*Args{small, big} {
+		args.Values, args.Pointers = args.Values[:1], args.Pointers[:1]
+		args.Values[0], args.Pointers[0] = reflect.ValueOf(42), new(int)
+		putArgs(args)
+		chk.Equal(reflect.Value{}, args.Values[0])
+		chk.Nil(args.Pointers[0])
+	}
+	// The oversized *Args is never returned from the pool.
+	for k := 0; k < 100; k++ {
+		chk.NotSame(big, argPool.Get())
+	}
+	// *Args from a private pool are returned to it regardless of width.
+	hot := &sync.Pool{}
+	big.pool = hot
+	returned := false
+	for k := 0; k < 100 && !returned; k++ {
+		putArgs(big)
+		returned = hot.Get() == big
+	}
+	chk.True(returned)
+	// The default limit follows ArgPoolWidth.
+	defer func(width int) { ArgPoolWidth = width }(ArgPoolWidth)
+	ArgPoolWidth = 10
+	chk.Equal(80, argPoolMaxWidth())
+	ArgPoolMaxWidth = 20
+	defer func() { ArgPoolMaxWidth = 0 }()
+	chk.Equal(20, argPoolMaxWidth())
+}
+
+func TestArgs_Reset(t *testing.T) {
+	chk := assert.New(t)
+	//
+	args := &Args{
+		Values: make([]reflect.Value, 10),
+	}
+	args.Reset(5)
+	chk.GreaterOrEqual(cap(args.Values), 5)
+	chk.GreaterOrEqual(cap(args.Pointers), 5)
+}
+
+func TestNewArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	values := []reflect.Value{reflect.ValueOf(1), reflect.ValueOf("a")}
+	args := NewArgs(values...)
+	chk.Equal(values, args.Values)
+	chk.Equal([]interface{}{nil, nil}, args.Pointers)
+	// values is copied and not retained.
+	putArgs(args)
+	chk.Equal(1, values[0].Interface())
+	//
+	args = NewArgs()
+	chk.Empty(args.Values)
+	chk.Empty(args.Pointers)
+}
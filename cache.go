@@ -13,6 +13,61 @@ type TypeInfoCache interface {
 	// StatType is similar to Stat except it accepts a reflect.Type and the returned *Instance
 	// has a Receiver that is the zero value for T.
 	StatType(T reflect.Type) *Instance
+
+	// StatMethod returns only the method named name of V with V as its receiver or
+	// ErrNotFound if V has no such method.
+	//
+	// StatMethod reflects and builds the single Method without inspecting the rest of V's
+	// method set; it is intended for types with many methods when only one is ever called.
+	StatMethod(V interface{}, name string) (Method, error)
+
+	// Types returns the types currently held in the cache in no particular order.
+	//
+	// The returned slice is a snapshot; it may be stale as soon as it is returned if other
+	// goroutines are using the cache.
+	Types() []reflect.Type
+
+	// SetMethodNameTransform sets a function that transforms Go method names into the
+	// Method.WireName of each Method created by the cache; pass nil to remove the transform.
+	//
+	// Types already in the cache are discarded so that subsequent calls to Stat or StatType
+	// use the new transform.
+	SetMethodNameTransform(transform func(goName string) string)
+
+	// Use appends middleware that is applied to the Func of every Method created by the
+	// cache; middleware registered first is the outermost.  See Middleware.
+	//
+	// Types already in the cache are discarded so that subsequent calls to Stat or StatType
+	// use the new middleware.
+	Use(mw ...Middleware)
+
+	// UseIf is the same as Use except the middleware is applied only to the methods of
+	// receiver types for which pred returns true.  Middleware registered with Use and UseIf
+	// is applied in the order it was registered.
+	UseIf(pred func(T reflect.Type) bool, mw ...Middleware)
+}
+
+// cacheConfig is the configuration of a TypeInfoCache that is applied to each Method it creates.
+type cacheConfig struct {
+	transform  func(string) string
+	middleware []cacheMiddleware
+}
+
+// cacheMiddleware is middleware registered with Use or UseIf; pred is nil for Use.
+type cacheMiddleware struct {
+	pred func(reflect.Type) bool
+	mw   Middleware
+}
+
+// middlewareFor returns the middleware that applies to the receiver type T.
+func (c *cacheConfig) middlewareFor(T reflect.Type) []Middleware {
+	var rv []Middleware
+	for _, m := range c.middleware {
+		if m.pred == nil || m.pred(T) {
+			rv = append(rv, m.mw)
+		}
+	}
+	return rv
 }
 
 // TypeCache is a global TypeInfoCache.
@@ -24,6 +79,48 @@ func Stat(value interface{}) *Instance {
 	return TypeCache.Stat(value)
 }
 
+// StatAddressable is similar to Stat except the receiver is an addressable copy of value; the
+// *Instance is bound to a pointer to the copy and therefore Methods also contains the methods
+// declared with pointer receivers.  Mutations made by those methods are made to the copy and
+// are visible through Instance.Receiver; value is not modified.
+//
+// If value is already a pointer it is used as-is rather than copied.  StatAddressable calls
+// TypeCache.Stat() on the global TypeInfoCache.
+func StatAddressable(value interface{}) *Instance {
+	if value == nil {
+		return nil
+	}
+	V := reflect.ValueOf(value)
+	if V.Kind() != reflect.Ptr {
+		P := reflect.New(V.Type())
+		P.Elem().Set(V)
+		V = P
+	}
+	rv := Stat(V.Interface())
+	rv.addressable = true
+	return rv
+}
+
+// TryInvoke calls the method named name on v with args; it is intended for dispatching to a
+// conventionally named method, such as Handle, on values of unknown type.
+//
+// ok is false and err is nil if v is nil or its type does not have the method.  Otherwise the
+// method is called as with Method.CallValues and ok is true; err is non-nil if args do not
+// match the method, in which case the method is not called.  v is found in TypeCache.
+func TryInvoke(v interface{}, name string, args ...interface{}) (result Result, ok bool, err error) {
+	if v == nil {
+		return Result{}, false, nil
+	}
+	instance := Stat(v)
+	defer instance.Release()
+	m, err := instance.Methods.Named(name)
+	if err != nil {
+		return Result{}, false, nil
+	}
+	result, err = m.CallValues(args...)
+	return result, true, err
+}
+
 // NewTypeInfoCache creates a new TypeInfoCache.
 func NewTypeInfoCache() TypeInfoCache {
 	return &typeInfoCache{
@@ -34,14 +131,28 @@ func NewTypeInfoCache() TypeInfoCache {
 // typeInfoCache is the implementation of a TypeInfoCache for this package.
 type typeInfoCache struct {
 	cache *sync.Map
+
+	// mu guards the configuration of the cache.
+	mu     sync.RWMutex
+	config cacheConfig
 }
 
 // Stat accepts an arbitrary variable and returns a *Instance whose receiver is V.
+//
+// The returned *Instance may be a recycled copy previously returned to the cache via
+// Instance.Release().
 func (me *typeInfoCache) Stat(V interface{}) *Instance {
 	if V == nil {
 		return nil
 	}
-	cp := me.StatType(reflect.TypeOf(V)).Copy()
+	template := me.StatType(reflect.TypeOf(V))
+	cp, _ := template.pool.Get().(*Instance)
+	if cp == nil {
+		cp = template.Copy()
+	} else {
+		cp.reset(template)
+	}
+	cp.origin = template
 	cp.Rebind(V)
 	return cp
 }
@@ -55,11 +166,20 @@ func (me *typeInfoCache) StatType(T reflect.Type) *Instance {
 	//
 	V := reflect.Zero(T)
 	//
+	// The read lock is held until the instance is stored so that clear can not run between
+	// reading the configuration and storing an instance created with it.
+	me.mu.RLock()
+	defer me.mu.RUnlock()
+	config := me.config
+	//
 	rv := &Instance{
 		Methods:       []Method{},
 		receiver:      V.Interface(),
 		receiverType:  T,
 		receiverValue: V,
+		pool:          &sync.Pool{},
+		config:        &config,
+		shadowed:      shadowedMethods(T),
 	}
 	//
 	num := T.NumMethod()
@@ -68,21 +188,104 @@ func (me *typeInfoCache) StatType(T reflect.Type) *Instance {
 	}
 	rv.Methods = make([]Method, num)
 	for k := 0; k < num; k++ {
-		method := T.Method(k)
-		//
-		info := Method{
-			instance: rv,
-			Name:     method.Name,
-			Method:   method,
-			Func:     newFunc(method.Func, method.Func.Type()),
-		}
-		// InCreate[0] represents the receiver which we do not need to create.
-		info.Func.InCreate = info.Func.InCreate[1:]
-		//
-		rv.Methods[k] = info
+		rv.Methods[k] = newMethod(rv, T.Method(k))
 	}
 	//
 	me.cache.Store(T, rv)
 	//
 	return rv
 }
+
+// StatMethod returns only the method named name of V with V as its receiver or
+// ErrNotFound if V has no such method.
+//
+// The result is not cached and each call reflects the method anew; callers that dispatch
+// repeatedly should retain the Method and use Instance().Rebind() to change its receiver.
+//
+// The Method's Instance contains only the one Method; therefore Method.Method.Index is the
+// index within V's method set and not within Instance.Methods.
+func (me *typeInfoCache) StatMethod(V interface{}, name string) (Method, error) {
+	if V == nil {
+		return Method{}, ErrNotFound
+	}
+	T := reflect.TypeOf(V)
+	method, ok := T.MethodByName(name)
+	if !ok {
+		return Method{}, ErrNotFound
+	}
+	//
+	me.mu.RLock()
+	config := me.config
+	me.mu.RUnlock()
+	//
+	rv := &Instance{
+		receiver:      V,
+		receiverType:  T,
+		receiverValue: reflect.ValueOf(V),
+		config:        &config,
+	}
+	rv.Methods = []Method{newMethod(rv, method)}
+	return rv.Methods[0], nil
+}
+
+// Types returns the types currently held in the cache in no particular order.
+//
+// The returned slice is a snapshot; it may be stale as soon as it is returned if other
+// goroutines are using the cache.  Types without methods are not held in the cache.
+func (me *typeInfoCache) Types() []reflect.Type {
+	var rv []reflect.Type
+	me.cache.Range(func(key, value interface{}) bool {
+		rv = append(rv, key.(reflect.Type))
+		return true
+	})
+	return rv
+}
+
+// SetMethodNameTransform sets a function that transforms Go method names into the
+// Method.WireName of each Method created by the cache; pass nil to remove the transform.
+//
+// Types already in the cache are discarded so that subsequent calls to Stat or StatType
+// use the new transform.
+func (me *typeInfoCache) SetMethodNameTransform(transform func(goName string) string) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	me.config.transform = transform
+	me.clear()
+}
+
+// Use appends middleware that is applied to the Func of every Method created by the
+// cache; middleware registered first is the outermost.  See Middleware.
+//
+// Types already in the cache are discarded so that subsequent calls to Stat or StatType
+// use the new middleware.
+func (me *typeInfoCache) Use(mw ...Middleware) {
+	me.UseIf(nil, mw...)
+}
+
+// UseIf is the same as Use except the middleware is applied only to the methods of receiver
+// types for which pred returns true.  Middleware registered with Use and UseIf is applied
+// in the order it was registered.
+//
+// pred is called with the receiver type whenever the cache creates a Method; a nil pred
+// matches every type.
+func (me *typeInfoCache) UseIf(pred func(T reflect.Type) bool, mw ...Middleware) {
+	me.mu.Lock()
+	defer me.mu.Unlock()
+	// Instances created earlier keep their own configuration; the slice is copied so that
+	// their copy of the configuration is not modified.
+	middleware := make([]cacheMiddleware, len(me.config.middleware), len(me.config.middleware)+len(mw))
+	copy(middleware, me.config.middleware)
+	for _, m := range mw {
+		middleware = append(middleware, cacheMiddleware{pred: pred, mw: m})
+	}
+	me.config.middleware = middleware
+	me.clear()
+}
+
+// clear discards all types in the cache; me.mu must be locked for writing.
+func (me *typeInfoCache) clear() {
+	me.cache.Range(func(key, value interface{}) bool {
+		me.cache.Delete(key)
+		return true
+	})
+}
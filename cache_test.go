@@ -1,7 +1,13 @@
 package call_test
 
 import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 
@@ -22,3 +28,322 @@ func BenchmarkStat(b *testing.B) {
 		call.Stat(talk)
 	}
 }
+
+func TestCache_StatType_SharedInterfaceValues(t *testing.T) {
+	chk := assert.New(t)
+	//
+	a := call.StatFunc(func(examples.Session, examples.Response) {})
+	b := call.StatFunc(func(examples.Response, examples.Session) {})
+	chk.Len(a.InCache, 2)
+	chk.Len(b.InCache, 2)
+	chk.True(a.InCache[0].V == b.InCache[1].V)
+	chk.True(a.InCache[1].V == b.InCache[0].V)
+	chk.Nil(a.InCache[0].V.Interface())
+}
+
+func BenchmarkStatType_Interfaces(b *testing.B) {
+	types := []reflect.Type{
+		reflect.TypeOf(examples.HTTP{}),
+		reflect.TypeOf(examples.ManyArgs{}),
+		reflect.TypeOf(examples.Talker{}),
+	}
+	b.ResetTimer()
+	for k := 0; k < b.N; k++ {
+		// A new cache every iteration so StatType does all of its work.
+		cache := call.NewTypeInfoCache()
+		for _, T := range types {
+			cache.StatType(T)
+		}
+	}
+}
+
+func BenchmarkStat_Release(b *testing.B) {
+	var talk examples.Talker
+	for k := 0; k < b.N; k++ {
+		call.Stat(talk).Release()
+	}
+}
+
+func ExampleTypeInfoCache_SetMethodNameTransform() {
+	snake := func(name string) string {
+		var b strings.Builder
+		for k, r := range name {
+			if unicode.IsUpper(r) {
+				if k > 0 {
+					b.WriteByte('_')
+				}
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+
+	cache := call.NewTypeInfoCache()
+	cache.SetMethodNameTransform(snake)
+
+	var h examples.HTTP
+	instance := cache.Stat(h)
+	m, err := instance.Methods.WireNamed("handler")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(m.Name, m.WireName)
+
+	// Output: Handler handler
+}
+
+func TestCache_SetMethodNameTransform(t *testing.T) {
+	chk := assert.New(t)
+	//
+	cache := call.NewTypeInfoCache()
+	T := reflect.TypeOf(examples.Person{})
+	before := cache.StatType(T)
+	chk.Equal("Greet", before.Methods[0].WireName)
+	chk.Same(before, cache.StatType(T))
+	//
+	cache.SetMethodNameTransform(strings.ToUpper)
+	after := cache.StatType(T)
+	chk.NotSame(before, after)
+	chk.Equal("GREET", after.Methods[0].WireName)
+	chk.Equal("Greet", after.Methods[0].Name)
+	chk.Equal("GREET", cache.Stat(examples.Person{}).Methods[0].WireName)
+	chk.Equal("GREET", after.Copy().Methods[0].WireName)
+	_, err := after.Methods.WireNamed("Greet")
+	chk.ErrorIs(err, call.ErrNotFound)
+	//
+	cache.SetMethodNameTransform(nil)
+	chk.Equal("Greet", cache.StatType(T).Methods[0].WireName)
+}
+
+func TestCache_SetMethodNameTransform_Concurrent(t *testing.T) {
+	chk := assert.New(t)
+	//
+	// An instance created while the transform is replaced must not remain cached.  Also run
+	// with -race.
+	cache := call.NewTypeInfoCache()
+	T := reflect.TypeOf(examples.Person{})
+	entered := make(chan struct{})
+	var once sync.Once
+	cache.SetMethodNameTransform(func(name string) string {
+		once.Do(func() { close(entered) })
+		time.Sleep(10 * time.Millisecond)
+		return "old"
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		cache.StatType(T)
+	}()
+	<-entered
+	cache.SetMethodNameTransform(strings.ToUpper)
+	<-done
+	chk.Equal("GREET", cache.StatType(T).Methods[0].WireName)
+}
+
+func ExampleTypeInfoCache_Use() {
+	logging := func(next func(*call.Args) call.Result) func(*call.Args) call.Result {
+		return func(args *call.Args) call.Result {
+			result := next(args)
+			if result.Error != nil {
+				fmt.Println("logged:", result.Error)
+			}
+			return result
+		}
+	}
+
+	cache := call.NewTypeInfoCache()
+	cache.Use(logging)
+
+	var talk examples.Talker
+	m, _ := cache.Stat(talk).Methods.Named("Error") // error ignored for brevity
+	m.Call(m.Args())
+
+	// Output: logged: examples.Talker made an error
+}
+
+func TestCache_Use(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var order []string
+	named := func(name string) call.Middleware {
+		return func(next func(*call.Args) call.Result) func(*call.Args) call.Result {
+			return func(args *call.Args) call.Result {
+				order = append(order, name+">")
+				result := next(args)
+				order = append(order, "<"+name)
+				return result
+			}
+		}
+	}
+	cache := call.NewTypeInfoCache()
+	T := reflect.TypeOf(examples.Person{})
+	before := cache.StatType(T)
+	cache.Use(named("a"), named("b"))
+	after := cache.StatType(T)
+	chk.NotSame(before, after)
+	//
+	greet, _ := cache.Stat(examples.Person{Name: "Bob"}).Methods.Named("Greet")
+	result := greet.Call(greet.Args())
+	chk.Equal([]string{"a>", "b>", "<b", "<a"}, order)
+	chk.Contains(result.Values[0], "Bob")
+	//
+	// Middleware added later does not affect instances created earlier.
+	order = nil
+	cache.Use(named("c"))
+	greet.Call(greet.Args())
+	chk.Equal([]string{"a>", "b>", "<b", "<a"}, order)
+	//
+	order = nil
+	greet, _ = cache.Stat(examples.Person{}).Methods.Named("Greet")
+	greet.Call(greet.Args())
+	chk.Equal([]string{"a>", "b>", "c>", "<c", "<b", "<a"}, order)
+	//
+	// The global cache and StatFunc are unaffected.
+	order = nil
+	greet, _ = call.Stat(examples.Person{}).Methods.Named("Greet")
+	greet.Call(greet.Args())
+	chk.Empty(order)
+}
+
+func TestCache_UseIf(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var order []string
+	named := func(name string) call.Middleware {
+		return func(next func(*call.Args) call.Result) func(*call.Args) call.Result {
+			return func(args *call.Args) call.Result {
+				order = append(order, name)
+				return next(args)
+			}
+		}
+	}
+	isPerson := func(T reflect.Type) bool {
+		return T == reflect.TypeOf(examples.Person{})
+	}
+	cache := call.NewTypeInfoCache()
+	cache.Use(named("a"))
+	cache.UseIf(isPerson, named("b"))
+	cache.Use(named("c"))
+	//
+	greet, _ := cache.Stat(examples.Person{Name: "Bob"}).Methods.Named("Greet")
+	result := greet.Call(greet.Args())
+	chk.Equal([]string{"a", "b", "c"}, order)
+	chk.Contains(result.Values[0], "Bob")
+	//
+	// Types that do not match the predicate are unaffected.
+	order = nil
+	counter := &examples.Counter{N: 41}
+	incr, _ := cache.Stat(counter).Methods.Named("Incr")
+	incr.Call(incr.Args())
+	chk.Equal([]string{"a", "c"}, order)
+	chk.Equal(42, counter.N)
+	//
+	order = nil
+	pointer, _ := cache.Stat(&examples.Person{}).Methods.Named("Greet")
+	pointer.Call(pointer.Args())
+	chk.Equal([]string{"a", "c"}, order)
+}
+
+func ExampleTypeInfoCache_StatMethod() {
+	cache := call.NewTypeInfoCache()
+	counter := &examples.Counter{N: 41}
+	m, err := cache.StatMethod(counter, "Incr")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	m.Call(m.Args())
+	fmt.Println(counter.N, len(m.Instance().Methods))
+
+	// Output: 42 1
+}
+
+func TestCache_StatMethod(t *testing.T) {
+	chk := assert.New(t)
+	//
+	cache := call.NewTypeInfoCache()
+	cache.SetMethodNameTransform(strings.ToLower)
+	m, err := cache.StatMethod(examples.Counter{N: 3}, "Count")
+	chk.NoError(err)
+	chk.Equal("count", m.WireName)
+	result := m.Call(m.Args())
+	chk.Equal([]interface{}{3}, result.Values)
+	// Incr is not in the method set of the value type.
+	_, err = cache.StatMethod(examples.Counter{}, "Incr")
+	chk.ErrorIs(err, call.ErrNotFound)
+	_, err = cache.StatMethod(nil, "Count")
+	chk.ErrorIs(err, call.ErrNotFound)
+}
+
+func BenchmarkStatMethod(b *testing.B) {
+	var many examples.ManyArgs
+	cache := call.NewTypeInfoCache()
+	name := reflect.TypeOf(many).Method(0).Name
+	b.ResetTimer()
+	for k := 0; k < b.N; k++ {
+		if _, err := cache.StatMethod(many, name); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCache_Types(t *testing.T) {
+	chk := assert.New(t)
+	//
+	cache := call.NewTypeInfoCache()
+	chk.Empty(cache.Types())
+	cache.Stat(examples.Counter{})
+	cache.Stat(&examples.Counter{})
+	// Types without methods are not cached.
+	cache.Stat(42)
+	chk.ElementsMatch([]reflect.Type{reflect.TypeOf(examples.Counter{}), reflect.TypeOf(&examples.Counter{})}, cache.Types())
+	// StatMethod does not populate the cache.
+	_, err := cache.StatMethod(examples.HTTP{}, "Handler")
+	chk.NoError(err)
+	chk.Len(cache.Types(), 2)
+	//
+	cache.SetMethodNameTransform(nil)
+	chk.Empty(cache.Types())
+}
+
+func ExampleTryInvoke() {
+	values := []interface{}{
+		examples.Person{Name: "Bob", Age: 40},
+		42,
+		&examples.Person{Name: "Sally", Age: 30},
+	}
+	for _, v := range values {
+		result, ok, err := call.TryInvoke(v, "Greet")
+		if err != nil {
+			fmt.Println(err)
+		} else if ok {
+			fmt.Println(result.Values[0])
+		} else {
+			fmt.Printf("%T can not Greet\n", v)
+		}
+	}
+
+	// Output: Hello!  My name is Bob and I am 40 year(s) old.
+	// int can not Greet
+	// Hello!  My name is Sally and I am 30 year(s) old.
+}
+
+func TestTryInvoke(t *testing.T) {
+	chk := assert.New(t)
+	//
+	result, ok, err := call.TryInvoke(joiner{sep: "-"}, "Pair", "a", "b")
+	chk.True(ok)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	_, ok, err = call.TryInvoke(joiner{}, "Pair", 1)
+	chk.True(ok)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, ok, err = call.TryInvoke(nil, "Pair")
+	chk.False(ok)
+	chk.NoError(err)
+	_, ok, err = call.TryInvoke(joiner{}, "Missing")
+	chk.False(ok)
+	chk.NoError(err)
+}
@@ -0,0 +1,49 @@
+package call
+
+import (
+	"fmt"
+)
+
+// ChainCall calls a with aArgs and then calls b with the non-error return values of a as its
+// arguments; return value k of a, not counting errors, becomes argument k of b, not counting
+// the receiver.  It is the reflective form of b(a(...)) for composing statted methods.
+//
+// The number of non-error return values of a must equal the number of non-receiver arguments
+// of b and each must be assignable to its argument; otherwise an error wrapping ErrIncompatible
+// is returned and neither method is called.  If a returns an error then b is not called and
+// the Result of a is returned with an error wrapping the returned error.
+//
+// As with Call() aArgs are returned to the argument pool.
+func ChainCall(a *Method, aArgs *Args, b *Method) (Result, error) {
+	offset := b.offset()
+	var outs []int
+	for k := range a.OutTypes {
+		if !a.errorSlots[k] {
+			outs = append(outs, k)
+		}
+	}
+	if len(outs) != b.NumIn-offset {
+		putArgs(aArgs)
+		return Result{}, fmt.Errorf("%w: %v returns %v values and %v expects %v arguments", ErrIncompatible, a.Pretty(), len(outs), b.Pretty(), b.NumIn-offset)
+	}
+	for n, k := range outs {
+		if T := b.InTypes[n+offset]; !a.OutTypes[k].AssignableTo(T) {
+			putArgs(aArgs)
+			return Result{}, fmt.Errorf("%w: return value %v of %v is %v and not assignable to argument %v of %v", ErrIncompatible, k, a.Pretty(), a.OutTypes[k], n+offset, b.Pretty())
+		}
+	}
+	result := a.Call(aArgs)
+	if result.Error != nil {
+		return result, fmt.Errorf("%v: %w", a.Name, result.Error)
+	}
+	bArgs := b.Args()
+	for n, k := range outs {
+		V, err := valueOf(result.Values[k], b.InTypes[n+offset])
+		if err != nil {
+			putArgs(bArgs)
+			return Result{}, fmt.Errorf("argument %v: %w", n+offset, err)
+		}
+		b.set(bArgs, n+offset, V)
+	}
+	return b.Call(bArgs), nil
+}
@@ -0,0 +1,47 @@
+package call_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+func TestChainCall(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(joiner{sep: "-"})
+	split, err := instance.Methods.Named("Split")
+	chk.NoError(err)
+	pairs, err := instance.Methods.Named("Pair")
+	chk.NoError(err)
+	parts, err := instance.Methods.Named("Parts")
+	chk.NoError(err)
+	//
+	args := split.Args()
+	*args.Pointers[1].(*string) = "a-b"
+	result, err := call.ChainCall(&split, args, &pairs)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	// The error of the first method stops the chain.
+	args = split.Args()
+	*args.Pointers[1].(*string) = "ab"
+	result, err = call.ChainCall(&split, args, &pairs)
+	chk.Error(err)
+	chk.Equal(result.Error, errors.Unwrap(err))
+	//
+	// Bound methods have no receiver.
+	concat := call.StatBoundMethod(func(p pair) string { return p.A + p.B }, "Concat")
+	args = parts.Args()
+	*args.Pointers[1].(*string), *args.Pointers[2].(*string) = "a", "b"
+	result, err = call.ChainCall(&parts, args, concat)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	//
+	_, err = call.ChainCall(&pairs, pairs.Args(), &pairs)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = call.ChainCall(&parts, parts.Args(), &split)
+	chk.ErrorIs(err, call.ErrIncompatible)
+}
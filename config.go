@@ -0,0 +1,71 @@
+package call
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FuncConfig is a snapshot of the mutable configuration of a Func; see Func.SaveConfig.
+type FuncConfig struct {
+	// T is the type of the function the configuration was saved from.
+	T reflect.Type
+
+	inCreate     []Arg
+	inCache      []Arg
+	onMissingArg func(arg Arg) (reflect.Value, bool)
+	onCreateArg  func(arg Arg, v reflect.Value)
+	argNames     []string
+	providers    map[string]func() reflect.Value
+	bindings     map[int]func() reflect.Value
+	transforms   []argTransform
+	errorCodes   []errorCode
+	timed        bool
+	zeroPooled   bool
+}
+
+// SaveConfig returns a snapshot of the configuration that determines how arguments are wired
+// and results are reported: the membership and defaults of InCreate and InCache as changed by
+// PruneIn, SetDefault, SetArgPool, MarkImmutable, and BindInterface; OnMissingArg and
+// OnCreateArg; argument names and named providers; argument transforms; error codes;
+// TimeCalls; and SetZeroPooled.
+// The signature metadata such as InTypes never changes and is not saved.
+//
+// SetSingleThreaded, SetHot, middleware, and initializers are not part of the snapshot.
+//
+// Every method that changes the configuration replaces the affected slices and maps instead of
+// modifying them; therefore a snapshot is not affected by later changes and SaveConfig does
+// not copy.
+func (f *Func) SaveConfig() FuncConfig {
+	return FuncConfig{
+		T:            f.Func.Type(),
+		inCreate:     f.InCreate,
+		inCache:      f.InCache,
+		onMissingArg: f.OnMissingArg,
+		onCreateArg:  f.OnCreateArg,
+		argNames:     f.argNames,
+		providers:    f.providers,
+		bindings:     f.bindings,
+		transforms:   f.transforms,
+		errorCodes:   f.errorCodes,
+		timed:        f.timed,
+		zeroPooled:   f.zeroPooled,
+	}
+}
+
+// RestoreConfig replaces the configuration of the Func with config, undoing any changes made
+// since config was saved; config may be restored any number of times.
+//
+// An error wrapping ErrIncompatible is returned if config was saved from a function of a
+// different type; the Func is not changed in that case.
+func (f *Func) RestoreConfig(config FuncConfig) error {
+	f.mutable()
+	if config.T != f.Func.Type() {
+		return fmt.Errorf("%w: %v can not restore a configuration of %v", ErrIncompatible, f.Pretty(), config.T)
+	}
+	f.InCreate, f.InCache = config.inCreate, config.inCache
+	f.OnMissingArg, f.OnCreateArg = config.onMissingArg, config.onCreateArg
+	f.argNames, f.providers, f.transforms = config.argNames, config.providers, config.transforms
+	f.bindings = config.bindings
+	f.errorCodes, f.timed, f.zeroPooled = config.errorCodes, config.timed, config.zeroPooled
+	return nil
+}
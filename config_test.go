@@ -0,0 +1,55 @@
+package call_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+func TestFunc_SaveConfig(t *testing.T) {
+	chk := assert.New(t)
+	//
+	errBusy := errors.New("busy")
+	f := call.StatFunc(func(s string, n int) (string, int, error) {
+		return s, n, errBusy
+	})
+	saved := f.SaveConfig()
+	//
+	chk.NoError(f.SetDefault(0, "default"))
+	chk.NoError(f.AddArgTransform(1, func(v reflect.Value) { v.SetInt(v.Int() + 1) }))
+	chk.NoError(f.SetArgName(1, "n"))
+	chk.NoError(f.SetNamedProvider("n", func() reflect.Value { return reflect.ValueOf(41) }))
+	f.MapError(errBusy, 503)
+	f.TimeCalls(true)
+	f.PruneIn(reflect.TypeOf(""))
+	f.OnMissingArg = func(arg call.Arg) (reflect.Value, bool) { return reflect.ValueOf("missing"), true }
+	//
+	args := f.Args()
+	args.Apply(f)
+	result := f.Call(args)
+	chk.Equal([]interface{}{"missing", 42, errBusy}, result.Values)
+	chk.Equal(503, result.Code)
+	experiment := f.SaveConfig()
+	//
+	chk.NoError(f.RestoreConfig(saved))
+	chk.Nil(f.OnMissingArg)
+	args = f.Args()
+	args.Apply(f)
+	result = f.Call(args)
+	chk.Equal([]interface{}{"", 0, errBusy}, result.Values)
+	chk.Equal(0, result.Code)
+	chk.Zero(result.Duration)
+	//
+	chk.NoError(f.RestoreConfig(experiment))
+	args = f.Args()
+	args.Apply(f)
+	result = f.Call(args)
+	chk.Equal([]interface{}{"missing", 42, errBusy}, result.Values)
+	//
+	other := call.StatFunc(func(s string) {})
+	chk.ErrorIs(other.RestoreConfig(saved), call.ErrIncompatible)
+}
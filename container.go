@@ -0,0 +1,76 @@
+package call
+
+import (
+	"reflect"
+)
+
+// ArgsFrom is the same as ArgsFromChain with a single container.
+func (f *Func) ArgsFrom(container map[reflect.Type]interface{}) (*Args, []Arg) {
+	return f.ArgsFromChain(container)
+}
+
+// ArgsFromChain returns arguments created by Args() and then replaces each argument whose type
+// is a key in one of the containers with the container's value.  The arguments that were not
+// found in any container are returned as the second return value.
+//
+// The containers are checked in order and the first match wins; later containers are
+// fallbacks for earlier ones.  For example a request-scoped container followed by an
+// application-scoped container lets request values override singletons.  A container value
+// that is not assignable to the argument type is ignored.
+//
+// Arguments replaced from a container have a nil entry in Pointers.  Arguments removed by
+// PruneIn are neither replaced nor returned.
+func (f *Func) ArgsFromChain(containers ...map[reflect.Type]interface{}) (*Args, []Arg) {
+	return f.fromChain(f.Args(), containers)
+}
+
+// fromChain replaces arguments in args with values from the containers.
+func (f *Func) fromChain(args *Args, containers []map[reflect.Type]interface{}) (*Args, []Arg) {
+	var unmatched []Arg
+	for _, arg := range f.AllArgs() {
+		if V, ok := fromContainers(arg.T, containers); ok {
+			args.Values[arg.N], args.Pointers[arg.N] = V, nil
+		} else {
+			unmatched = append(unmatched, arg)
+		}
+	}
+	return args, unmatched
+}
+
+// fromContainers returns the first value in containers for type T.
+func fromContainers(T reflect.Type, containers []map[reflect.Type]interface{}) (reflect.Value, bool) {
+	for _, container := range containers {
+		v, ok := container[T]
+		if !ok {
+			continue
+		}
+		if V, err := valueOf(v, T); err == nil {
+			return V, true
+		}
+	}
+	return zeroReflectValue, false
+}
+
+// ArgsFromValues is the same as ArgsFrom except the container holds reflect.Values; values
+// already held as reflect.Value, such as those decoded by an earlier handler in a middleware
+// pipeline, are handed off without converting them to interface{} and back.
+//
+// An invalid value or one that is not assignable to the argument type is ignored.
+func (f *Func) ArgsFromValues(vals map[reflect.Type]reflect.Value) (*Args, []Arg) {
+	return f.fromValues(f.Args(), vals)
+}
+
+// fromValues replaces arguments in args with values from vals.
+func (f *Func) fromValues(args *Args, vals map[reflect.Type]reflect.Value) (*Args, []Arg) {
+	var unmatched []Arg
+	for _, arg := range f.AllArgs() {
+		if V, ok := vals[arg.T]; ok && V.IsValid() {
+			if V, err := adapt(V, arg.T); err == nil {
+				args.Values[arg.N], args.Pointers[arg.N] = V, nil
+				continue
+			}
+		}
+		unmatched = append(unmatched, arg)
+	}
+	return args, unmatched
+}
@@ -0,0 +1,108 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_ArgsFromChain() {
+	type Config struct{ Name string }
+	fn := func(cfg Config, sess examples.Session, n int) {
+		fmt.Println(cfg.Name, sess != nil, n)
+	}
+
+	app := map[reflect.Type]interface{}{
+		reflect.TypeOf(Config{}): Config{Name: "app"},
+		reflect.TypeOf(0):        1,
+	}
+	request := map[reflect.Type]interface{}{
+		reflect.TypeOf((*examples.Session)(nil)).Elem(): examples.MapSession{},
+		reflect.TypeOf(0): 2,
+	}
+
+	f := call.StatFunc(fn)
+	// The request container overrides the app container.
+	args, unmatched := f.ArgsFromChain(request, app)
+	fmt.Println(len(unmatched))
+	f.Call(args)
+
+	// Output: 0
+	// app true 2
+}
+
+func TestFunc_ArgsFromChain(t *testing.T) {
+	chk := assert.New(t)
+	//
+	fn := func(s string, sess examples.Session, n int) (string, examples.Session, int) {
+		return s, sess, n
+	}
+	f := call.StatFunc(fn)
+	args, unmatched := f.ArgsFromChain()
+	chk.Len(unmatched, 3)
+	f.Call(args)
+	// Incompatible values fall through to later containers.
+	args, unmatched = f.ArgsFromChain(
+		map[reflect.Type]interface{}{reflect.TypeOf(""): 42},
+		map[reflect.Type]interface{}{reflect.TypeOf(""): "ok"},
+	)
+	if chk.Len(unmatched, 2) {
+		chk.Equal(1, unmatched[0].N)
+		chk.Equal(2, unmatched[1].N)
+	}
+	chk.Nil(args.Pointers[0])
+	chk.NotNil(args.Pointers[2])
+	chk.Equal([]interface{}{"ok", nil, 0}, f.Call(args).Values)
+	// Pruned arguments are neither replaced nor returned.
+	f.PruneIn(reflect.TypeOf(""))
+	args, unmatched = f.ArgsFrom(map[reflect.Type]interface{}{reflect.TypeOf(""): "x"})
+	chk.Len(unmatched, 2)
+	chk.False(args.Values[0].IsValid())
+}
+
+func TestMethod_ArgsFrom(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Pair")
+	chk.NoError(err)
+	args, unmatched := m.ArgsFrom(map[reflect.Type]interface{}{
+		reflect.TypeOf(""):       "a",
+		reflect.TypeOf(joiner{}): joiner{sep: "+"},
+	})
+	chk.Empty(unmatched)
+	chk.Equal([]interface{}{"a-a"}, m.Call(args).Values)
+}
+
+func TestFunc_ArgsFromValues(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Form struct {
+		Name string
+	}
+	f := call.StatFunc(func(form Form, n int, s string) string { return form.Name + s })
+	decoded := reflect.ValueOf(&Form{Name: "bob"})
+	args, unmatched := f.ArgsFromValues(map[reflect.Type]reflect.Value{
+		reflect.TypeOf(Form{}): decoded.Elem(),
+		reflect.TypeOf(0):      reflect.ValueOf("not an int"),
+		reflect.TypeOf(""):     {},
+	})
+	if chk.Len(unmatched, 2) {
+		chk.Equal(1, unmatched[0].N)
+		chk.Equal(2, unmatched[1].N)
+	}
+	chk.Nil(args.Pointers[0])
+	chk.Equal([]interface{}{"bob"}, f.Call(args).Values)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Pair")
+	chk.NoError(err)
+	args, unmatched = m.ArgsFromValues(map[reflect.Type]reflect.Value{
+		reflect.TypeOf(""): reflect.ValueOf("a"),
+	})
+	chk.Empty(unmatched)
+	chk.Equal([]interface{}{"a-a"}, m.Call(args).Values)
+}
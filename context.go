@@ -0,0 +1,99 @@
+package call
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// contextType is the reflect.Type of context.Context.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// CallContextTimeout derives a context from ctx with context.WithTimeout(ctx, d), assigns it
+// to every argument of type context.Context, and invokes the function with Call().  The
+// derived context is canceled when the function returns.
+//
+// The function runs on the calling goroutine and is not interrupted when the timeout elapses;
+// it must honor the context's Done channel to return early.  This is the idiomatic approach
+// for functions that accept a context.  A hard timeout that abandons the call in another
+// goroutine leaks that goroutine for as long as the function runs and should only be
+// preferred for functions that can not accept a context.
+//
+// An error wrapping ErrNotFound is returned if the function has no context.Context argument;
+// the function is not called in that case.
+//
+// As with Call() the args are returned to the argument pool.
+func (f *Func) CallContextTimeout(ctx context.Context, args *Args, d time.Duration) (Result, error) {
+	found := false
+	for _, T := range f.InTypes {
+		found = found || T == contextType
+	}
+	if !found {
+		putArgs(args)
+		return Result{}, fmt.Errorf("%w: %v has no context.Context argument", ErrNotFound, f.Pretty())
+	}
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+	f.setContext(args, ctx)
+	return f.Call(args), nil
+}
+
+// setContext assigns ctx to every argument of type context.Context.
+func (f *Func) setContext(args *Args, ctx context.Context) {
+	V := reflect.ValueOf(&ctx).Elem()
+	for k, T := range f.InTypes {
+		if T == contextType {
+			args.Values[k] = V
+			if k < len(args.Pointers) {
+				args.Pointers[k] = nil
+			}
+		}
+	}
+}
+
+// DecodeAndCall creates arguments with Args(), unmarshals the JSON in data into each struct
+// argument, and invokes the function; the decode and the call together are budgeted by d.
+//
+// The timeout covers both phases but neither is interrupted:
+//
+//	decode  data is unmarshaled on the calling goroutine; if the budget is exhausted when
+//	        decoding finishes the function is not called.
+//	call    arguments of type context.Context receive a context whose deadline is the end
+//	        of the budget; the function must honor it to return early.
+//
+// In either case the returned error wraps context.DeadlineExceeded; when the call itself
+// overruns the budget its Result is returned along with the error.
+//
+// Empty data leaves the struct arguments as created.  Unmarshal errors are returned as an
+// *ArgError and the function is not called.
+func (f *Func) DecodeAndCall(data []byte, d time.Duration) (Result, error) {
+	return f.decodeAndCall(f.Args(), data, d)
+}
+
+// decodeAndCall is the implementation of DecodeAndCall.
+func (f *Func) decodeAndCall(args *Args, data []byte, d time.Duration) (Result, error) {
+	deadline := time.Now().Add(d)
+	if len(data) > 0 {
+		err := decodeArgs(args, func() error {
+			return f.structArgs(args, func(n int, pointer interface{}) error {
+				return args.UnmarshalJSONInto(n, data)
+			})
+		})
+		if err != nil {
+			return Result{}, err
+		}
+	}
+	if !time.Now().Before(deadline) {
+		putArgs(args)
+		return Result{}, fmt.Errorf("%v: decode exceeded %v: %w", f.Pretty(), d, context.DeadlineExceeded)
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	f.setContext(args, ctx)
+	result := f.Call(args)
+	if time.Now().After(deadline) {
+		return result, fmt.Errorf("%v: call exceeded %v: %w", f.Pretty(), d, context.DeadlineExceeded)
+	}
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package call_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+// slow is used to demonstrate CallContextTimeout.
+type slow struct{}
+
+func (slow) Wait(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func ExampleFunc_CallContextTimeout() {
+	m, _ := call.Stat(slow{}).Methods.Named("Wait") // error ignored for brevity
+	args := m.Args()
+	*args.Pointers[2].(*time.Duration) = time.Second
+	result, err := m.CallContextTimeout(context.Background(), args, 10*time.Millisecond)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Error)
+
+	// Output: context deadline exceeded
+}
+
+func TestFunc_CallContextTimeout(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var captured context.Context
+	f := call.StatFunc(func(a context.Context, n int, b context.Context) bool {
+		captured = a
+		return a == b
+	})
+	result, err := f.CallContextTimeout(context.Background(), f.Args(), time.Minute)
+	chk.NoError(err)
+	chk.Equal([]interface{}{true}, result.Values)
+	_, ok := captured.Deadline()
+	chk.True(ok)
+	// Canceled on return.
+	chk.ErrorIs(captured.Err(), context.Canceled)
+	//
+	f = call.StatFunc(func(n int) {})
+	_, err = f.CallContextTimeout(context.Background(), f.Args(), time.Minute)
+	chk.ErrorIs(err, call.ErrNotFound)
+}
+
+// order is decoded by DecodeAndCall.
+type order struct {
+	Item string        `json:"item"`
+	Wait time.Duration `json:"wait"`
+}
+
+func (slow) Place(ctx context.Context, o order) (string, error) {
+	return o.Item, slow{}.Wait(ctx, o.Wait)
+}
+
+func TestMethod_DecodeAndCall(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(slow{}).Methods.Named("Place")
+	chk.NoError(err)
+	result, err := m.DecodeAndCall([]byte(`{"item":"tea"}`), time.Minute)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"tea", nil}, result.Values)
+	// The call overruns the budget; the Result is returned with the error.
+	result, err = m.DecodeAndCall([]byte(`{"item":"tea","wait":1000000000}`), 10*time.Millisecond)
+	chk.ErrorIs(err, context.DeadlineExceeded)
+	chk.True(result.IsContextError())
+	// The budget is exhausted before the call.
+	_, err = m.DecodeAndCall(nil, 0)
+	chk.ErrorIs(err, context.DeadlineExceeded)
+	//
+	var argErr *call.ArgError
+	_, err = m.DecodeAndCall([]byte(`{"item":1}`), time.Minute)
+	if chk.ErrorAs(err, &argErr) {
+		chk.Equal(2, argErr.N)
+	}
+	//
+	f := call.StatFunc(func(o order) string { return o.Item })
+	result, err = f.DecodeAndCall(nil, time.Minute)
+	chk.NoError(err)
+	chk.Equal([]interface{}{""}, result.Values)
+}
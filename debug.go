@@ -0,0 +1,25 @@
+package call
+
+import (
+	"log"
+)
+
+var (
+	// Debug enables logging of performance problems that are otherwise silent, such as Args()
+	// growing the Values and Pointers slices because a function has more arguments than
+	// ArgPoolWidth.  Messages are written with DebugLogf.
+	//
+	// Debug is intended for development and staging; it should be set before any calls to
+	// Args() and not modified afterwards.
+	Debug = false
+
+	// DebugLogf is the logger used when Debug is true; it defaults to log.Printf.
+	DebugLogf = log.Printf
+)
+
+// debugGrow logs that Args() for f must grow args to hold the arguments of f.
+func (f *Func) debugGrow(args *Args) {
+	if f.NumIn > cap(args.Values) || f.NumIn > cap(args.Pointers) {
+		DebugLogf("call: Args() for %v grows *Args from capacity %v to %v; see ArgPoolWidth", f.Pretty(), cap(args.Values), f.NumIn)
+	}
+}
@@ -0,0 +1,42 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+func TestDebug(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var logged []string
+	defer func(debug bool, logf func(string, ...interface{})) {
+		call.Debug, call.DebugLogf = debug, logf
+	}(call.Debug, call.DebugLogf)
+	call.Debug = true
+	call.DebugLogf = func(format string, v ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, v...))
+	}
+	//
+	m, err := call.Stat(joiner{}).Methods.Named("Pair")
+	chk.NoError(err)
+	m.Call(m.Args())
+	chk.Empty(logged)
+	//
+	// *Args wider than ArgPoolMaxWidth, by default 8 times ArgPoolWidth, are never pooled
+	// and always grow.
+	in := make([]reflect.Type, 8*call.ArgPoolWidth+1)
+	for k := range in {
+		in[k] = reflect.TypeOf(0)
+	}
+	T := reflect.FuncOf(in, nil, false)
+	f := call.StatFunc(reflect.MakeFunc(T, func([]reflect.Value) []reflect.Value { return nil }).Interface())
+	f.Call(f.Args())
+	if chk.Len(logged, 1) {
+		chk.Contains(logged[0], f.Pretty())
+	}
+}
@@ -0,0 +1,254 @@
+package call
+
+import (
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// durationType is the reflect.Type of time.Duration.
+	durationType = reflect.TypeOf(time.Duration(0))
+
+	// textUnmarshalerType is the reflect.Type of encoding.TextUnmarshaler.
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// ArgDecoder decodes the next value from a stream into dst; *json.Decoder, *gob.Decoder, and
+// *xml.Decoder satisfy ArgDecoder.
+type ArgDecoder interface {
+	Decode(dst interface{}) error
+}
+
+// DecodeArgs calls d.Decode once for each struct argument in args in argument order, passing
+// its entry in Pointers; it is the codec-agnostic form of Args.UnmarshalJSONInto.
+//
+// Arguments of kind struct with an entry in Pointers are decoded; pointer-to-struct arguments
+// and arguments without a pointer are skipped.  Decoding stops at the first error, which is
+// returned as an *ArgError.
+//
+// The caller remains responsible for args; see Args.Release.
+func (f *Func) DecodeArgs(args *Args, d ArgDecoder) error {
+	return f.structArgs(args, func(n int, pointer interface{}) error {
+		if err := d.Decode(pointer); err != nil {
+			return &ArgError{N: n, Err: err}
+		}
+		return nil
+	})
+}
+
+// pointer returns Pointers[index] or an error wrapping ErrNotFound if index is out of
+// range or the argument does not have a pointer.
+func (args *Args) pointer(index int) (interface{}, error) {
+	if index < 0 || index >= len(args.Pointers) || args.Pointers[index] == nil {
+		return nil, fmt.Errorf("argument %v: %w", index, ErrNotFound)
+	}
+	return args.Pointers[index], nil
+}
+
+// structAt returns the addressable struct value behind Pointers[index].  When the argument
+// is a nil pointer-to-struct a new struct is allocated and assigned to the argument.
+func (args *Args) structAt(index int) (reflect.Value, error) {
+	p, err := args.pointer(index)
+	if err != nil {
+		return zeroReflectValue, err
+	}
+	V := reflect.ValueOf(p).Elem()
+	if V.Kind() == reflect.Ptr && V.Type().Elem().Kind() == reflect.Struct {
+		if V.IsNil() {
+			V.Set(reflect.New(V.Type().Elem()))
+		}
+		V = V.Elem()
+	}
+	if V.Kind() != reflect.Struct {
+		return zeroReflectValue, fmt.Errorf("%w: argument %v is %v and not a struct", ErrIncompatible, index, V.Type())
+	}
+	return V, nil
+}
+
+// decodeTagged walks the exported fields of the struct S and calls set for each field
+// with a non-empty tag; fields tagged "-" are skipped.  Untagged embedded structs are
+// walked recursively.
+func decodeTagged(S reflect.Value, tag string, set func(field reflect.Value, key string) error) error {
+	T := S.Type()
+	for k, max := 0, T.NumField(); k < max; k++ {
+		sf := T.Field(k)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		key := strings.Split(sf.Tag.Get(tag), ",")[0]
+		if key == "-" {
+			continue
+		} else if key == "" {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				if err := decodeTagged(S.Field(k), tag, set); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err := set(S.Field(k), key); err != nil {
+			return fmt.Errorf("field %v: %w", sf.Name, err)
+		}
+	}
+	return nil
+}
+
+// setString converts s to the type of V and assigns it; V must be settable.
+//
+// Strings are assigned as-is; bools, ints, uints, and floats are parsed with package
+// strconv; time.Duration is parsed with time.ParseDuration.  Pointers are allocated
+// as needed.  Any other kind returns an error wrapping ErrIncompatible.
+func setString(V reflect.Value, s string) error {
+	if V.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		V.SetInt(int64(d))
+		return nil
+	}
+	switch V.Kind() {
+	case reflect.String:
+		V.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		V.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, V.Type().Bits())
+		if err != nil {
+			return err
+		}
+		V.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(s, 10, V.Type().Bits())
+		if err != nil {
+			return err
+		}
+		V.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, V.Type().Bits())
+		if err != nil {
+			return err
+		}
+		V.SetFloat(n)
+	case reflect.Ptr:
+		if V.IsNil() {
+			V.Set(reflect.New(V.Type().Elem()))
+		}
+		return setString(V.Elem(), s)
+	default:
+		return fmt.Errorf("%w: can not set %v from string", ErrIncompatible, V.Type())
+	}
+	return nil
+}
+
+// setText is the same as setString except types whose pointer implements
+// encoding.TextUnmarshaler are set with UnmarshalText; V must be addressable.
+func setText(V reflect.Value, s string) error {
+	if V.Kind() == reflect.Ptr {
+		if V.IsNil() {
+			V.Set(reflect.New(V.Type().Elem()))
+		}
+		return setText(V.Elem(), s)
+	} else if V.Addr().Type().Implements(textUnmarshalerType) {
+		return V.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	return setString(V, s)
+}
+
+// SetText sets the argument at index from text.
+//
+// If the argument's type implements encoding.TextUnmarshaler through its pointer then
+// UnmarshalText is called; otherwise text is converted with the same rules as DecodeEnv.
+// Pointer arguments are allocated as needed and the same rules apply to the pointed-to type.
+//
+// SetText is intended for binding path or query parameters to arguments of rich scalar types
+// such as time.Time.  The argument at index must have a non-nil entry in Pointers.
+func (args *Args) SetText(index int, text string) error {
+	p, err := args.pointer(index)
+	if err != nil {
+		return err
+	}
+	if err = setText(reflect.ValueOf(p).Elem(), text); err != nil {
+		return fmt.Errorf("argument %v: %w", index, err)
+	}
+	return nil
+}
+
+// DecodeEnv populates the struct argument at index from environment variables.
+//
+// Each exported field with an `env:"NAME"` tag is set from the environment variable NAME;
+// if the variable is not set the field is left unchanged, which is typically its zero value.
+// A variable that is set to the empty string is still assigned.
+//
+// Values are converted to the field's type with the following rules:
+//
+//	string        assigned as-is
+//	bool          strconv.ParseBool
+//	int*, uint*   strconv.ParseInt or strconv.ParseUint in base 10
+//	float*        strconv.ParseFloat
+//	time.Duration time.ParseDuration
+//	pointers      allocated and then set according to the pointed-to type
+//
+// Fields of any other type return an error.  Embedded structs without an env tag are
+// decoded recursively.
+//
+// The argument at index must be a struct or pointer-to-struct with a non-nil entry in
+// Pointers; a nil pointer-to-struct argument is allocated.
+func (args *Args) DecodeEnv(index int) error {
+	S, err := args.structAt(index)
+	if err != nil {
+		return err
+	}
+	return decodeTagged(S, "env", func(field reflect.Value, key string) error {
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			return nil
+		}
+		return setString(field, value)
+	})
+}
+
+// DecodeTagged populates the struct argument at index from source using the struct tag
+// tagName; it is a general form of DecodeEnv where the tag and the source of values are
+// chosen by the caller.  url.Values and http.Header can be passed as source directly.
+//
+// Each exported field with a `tagName:"key"` tag is set from source[key]; if key is not in
+// source the field is left unchanged.  Fields that are slices, other than those that
+// implement encoding.TextUnmarshaler, are set to a new slice with one element per value in
+// source[key]; all other fields are set from the first value.
+//
+// Values are converted with the same rules as SetText.  Embedded structs without a tag are
+// decoded recursively.  The argument at index must be a struct or pointer-to-struct with a
+// non-nil entry in Pointers; a nil pointer-to-struct argument is allocated.
+func (args *Args) DecodeTagged(index int, tagName string, source map[string][]string) error {
+	S, err := args.structAt(index)
+	if err != nil {
+		return err
+	}
+	return decodeTagged(S, tagName, func(field reflect.Value, key string) error {
+		values, ok := source[key]
+		if !ok || len(values) == 0 {
+			return nil
+		}
+		if field.Kind() != reflect.Slice || field.Addr().Type().Implements(textUnmarshalerType) {
+			return setText(field, values[0])
+		}
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for k, value := range values {
+			if err := setText(slice.Index(k), value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+		return nil
+	})
+}
@@ -0,0 +1,223 @@
+package call_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleArgs_DecodeEnv() {
+	type Options struct {
+		Host string `env:"EXAMPLE_HOST"`
+		Port int    `env:"EXAMPLE_PORT"`
+		// Debug is not in the environment and is left as false.
+		Debug bool `env:"EXAMPLE_DEBUG"`
+	}
+	serve := func(opts Options) {
+		fmt.Printf("%v:%v debug=%v\n", opts.Host, opts.Port, opts.Debug)
+	}
+
+	os.Setenv("EXAMPLE_HOST", "localhost")
+	defer os.Unsetenv("EXAMPLE_HOST")
+	os.Setenv("EXAMPLE_PORT", "8080")
+	defer os.Unsetenv("EXAMPLE_PORT")
+
+	f := call.StatFunc(serve)
+	args := f.Args()
+	if err := args.DecodeEnv(0); err != nil {
+		fmt.Println(err)
+		return
+	}
+	f.Call(args)
+
+	// Output: localhost:8080 debug=false
+}
+
+func TestArgs_DecodeEnv(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Embedded struct {
+		Name string `env:"TEST_DECODE_NAME"`
+	}
+	type Options struct {
+		Embedded
+		Timeout    time.Duration `env:"TEST_DECODE_TIMEOUT"`
+		Ratio      float32       `env:"TEST_DECODE_RATIO"`
+		Count      *uint8        `env:"TEST_DECODE_COUNT"`
+		Skip       string        `env:"-"`
+		Untagged   string
+		unexported string `env:"TEST_DECODE_NAME"`
+	}
+	os.Setenv("TEST_DECODE_NAME", "name")
+	defer os.Unsetenv("TEST_DECODE_NAME")
+	os.Setenv("TEST_DECODE_TIMEOUT", "5s")
+	defer os.Unsetenv("TEST_DECODE_TIMEOUT")
+	os.Setenv("TEST_DECODE_RATIO", "0.5")
+	defer os.Unsetenv("TEST_DECODE_RATIO")
+	os.Setenv("TEST_DECODE_COUNT", "7")
+	defer os.Unsetenv("TEST_DECODE_COUNT")
+	//
+	var got *Options
+	f := call.StatFunc(func(n int, opts *Options, s fmt.Stringer) {
+		got = opts
+	})
+	args := f.Args()
+	chk.NoError(args.DecodeEnv(1))
+	f.Call(args)
+	if chk.NotNil(got) {
+		chk.Equal("name", got.Name)
+		chk.Equal(5*time.Second, got.Timeout)
+		chk.Equal(float32(0.5), got.Ratio)
+		if chk.NotNil(got.Count) {
+			chk.Equal(uint8(7), *got.Count)
+		}
+		chk.Empty(got.Skip)
+		chk.Empty(got.unexported)
+	}
+	//
+	args = f.Args()
+	defer f.Call(args)
+	chk.ErrorIs(args.DecodeEnv(0), call.ErrIncompatible)
+	chk.ErrorIs(args.DecodeEnv(2), call.ErrNotFound)
+	chk.ErrorIs(args.DecodeEnv(3), call.ErrNotFound)
+	//
+	os.Setenv("TEST_DECODE_COUNT", "-1")
+	chk.Error(args.DecodeEnv(1))
+}
+
+func ExampleArgs_SetText() {
+	fn := func(at time.Time, n int, until *time.Time) {
+		fmt.Println(at.Year(), n, until.Month())
+	}
+
+	f := call.StatFunc(fn)
+	args := f.Args()
+	for k, text := range []string{"2021-06-01T00:00:00Z", "42", "2022-03-01T00:00:00Z"} {
+		if err := args.SetText(k, text); err != nil {
+			fmt.Println(err)
+			return
+		}
+	}
+	f.Call(args)
+
+	// Output: 2021 42 March
+}
+
+func TestArgs_SetText(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(time.Time, *int, string, examples.Session, []string) {})
+	args := f.Args()
+	chk.NoError(args.SetText(1, "7"))
+	chk.Equal(7, **args.Pointers[1].(**int))
+	chk.NoError(args.SetText(2, "str"))
+	chk.Equal("str", *args.Pointers[2].(*string))
+	//
+	chk.Error(args.SetText(0, "not a time"))
+	chk.Error(args.SetText(1, "x"))
+	chk.ErrorIs(args.SetText(3, "x"), call.ErrNotFound)
+	chk.ErrorIs(args.SetText(4, "x"), call.ErrIncompatible)
+	chk.ErrorIs(args.SetText(5, "x"), call.ErrNotFound)
+}
+
+func ExampleArgs_DecodeTagged() {
+	type Query struct {
+		Term  string    `param:"q"`
+		Page  int       `param:"page"`
+		Tags  []string  `param:"tag"`
+		Since time.Time `param:"since"`
+	}
+	search := func(query Query) {
+		fmt.Println(query.Term, query.Page, query.Tags, query.Since.Year())
+	}
+
+	// url.Values or http.Header may be passed directly.
+	source := map[string][]string{
+		"q":     {"gophers"},
+		"page":  {"2"},
+		"tag":   {"go", "reflect"},
+		"since": {"2020-01-01T00:00:00Z"},
+	}
+
+	f := call.StatFunc(search)
+	args := f.Args()
+	if err := args.DecodeTagged(0, "param", source); err != nil {
+		fmt.Println(err)
+		return
+	}
+	f.Call(args)
+
+	// Output: gophers 2 [go reflect] 2020
+}
+
+func TestArgs_DecodeTagged(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Embedded struct {
+		ID int `param:"id"`
+	}
+	type Params struct {
+		Embedded
+		Name   *string `param:"name"`
+		Counts []int   `param:"count"`
+		Skip   string  `param:"-"`
+		Empty  string  `param:"empty"`
+	}
+	f := call.StatFunc(func(*Params, int) {})
+	args := f.Args()
+	chk.NoError(args.DecodeTagged(0, "param", map[string][]string{
+		"id":    {"7", "8"},
+		"name":  {"n"},
+		"count": {"1", "2"},
+		"-":     {"x"},
+		"empty": {},
+	}))
+	p := *args.Pointers[0].(**Params)
+	chk.Equal(7, p.ID)
+	chk.Equal("n", *p.Name)
+	chk.Equal([]int{1, 2}, p.Counts)
+	chk.Empty(p.Skip)
+	//
+	chk.Error(args.DecodeTagged(0, "param", map[string][]string{"count": {"1", "x"}}))
+	chk.ErrorIs(args.DecodeTagged(1, "param", nil), call.ErrIncompatible)
+}
+
+func TestFunc_DecodeArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Point struct{ X, Y int }
+	type Label struct{ Text string }
+	f := call.StatFunc(func(p Point, n int, l Label, skip *Label) string {
+		return fmt.Sprintf("%v,%v %v %v", p.X, p.Y, n, l.Text)
+	})
+	// Struct arguments are decoded in order from the stream.
+	args := f.Args()
+	d := json.NewDecoder(bytes.NewBufferString(`{"X":1,"Y":2} {"Text":"origin"}`))
+	chk.NoError(f.DecodeArgs(args, d))
+	chk.Equal([]interface{}{"1,2 0 origin"}, f.Call(args).Values)
+	//
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	chk.NoError(enc.Encode(Point{X: 3, Y: 4}))
+	chk.NoError(enc.Encode(Label{Text: "gob"}))
+	args = f.Args()
+	chk.NoError(f.DecodeArgs(args, gob.NewDecoder(&buf)))
+	chk.Equal([]interface{}{"3,4 0 gob"}, f.Call(args).Values)
+	//
+	var argErr *call.ArgError
+	args = f.Args()
+	d = json.NewDecoder(bytes.NewBufferString(`{"X":1} {"Text":5}`))
+	if chk.ErrorAs(f.DecodeArgs(args, d), &argErr) {
+		chk.Equal(2, argErr.N)
+	}
+	args.Release()
+}
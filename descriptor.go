@@ -0,0 +1,131 @@
+package call
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// descriptorVersion is the version of the binary format written by Func.Encode.
+const descriptorVersion = 1
+
+// descriptorVariadic is set in the flags byte of an encoded FuncDescriptor when the function
+// is variadic.
+const descriptorVariadic = 1 << 0
+
+// FuncDescriptor describes the signature of a function without access to the function itself;
+// it is decoded from the output of Func.Encode with DecodeFuncDescriptor.
+type FuncDescriptor struct {
+	// InTypes and InKinds describe the arguments; InTypes contains the reflect.Type.String() of
+	// each argument.
+	InTypes []string
+	InKinds []reflect.Kind
+
+	// OutTypes contains the reflect.Type.String() of each return value.
+	OutTypes []string
+
+	// Variadic is true if the final argument is variadic.
+	Variadic bool
+}
+
+// Encode returns a compact binary description of the function's signature suitable for
+// advertising the signature to other processes; see DecodeFuncDescriptor.
+//
+// The format is versioned.  It begins with a version byte and a flags byte followed by the
+// arguments and then the return values, each as a count followed by its elements.  Counts,
+// kinds, and string lengths are written as unsigned varints.
+//
+// For a Method the receiver is the first argument.
+func (f *Func) Encode() []byte {
+	var buf bytes.Buffer
+	var scratch [binary.MaxVarintLen64]byte
+	putUvarint := func(n int) {
+		buf.Write(scratch[:binary.PutUvarint(scratch[:], uint64(n))])
+	}
+	putString := func(s string) {
+		putUvarint(len(s))
+		buf.WriteString(s)
+	}
+	//
+	var flags byte
+	if f.Func.Type().IsVariadic() {
+		flags |= descriptorVariadic
+	}
+	buf.WriteByte(descriptorVersion)
+	buf.WriteByte(flags)
+	putUvarint(f.NumIn)
+	for k, T := range f.InTypes {
+		putUvarint(int(f.InKinds[k]))
+		putString(T.String())
+	}
+	putUvarint(f.NumOut)
+	for _, T := range f.OutTypes {
+		putString(T.String())
+	}
+	return buf.Bytes()
+}
+
+// DecodeFuncDescriptor decodes the output of Func.Encode.
+//
+// An error wrapping ErrIncompatible is returned if data was written by an unsupported version
+// of the format; io.ErrUnexpectedEOF is returned if data is truncated.
+func DecodeFuncDescriptor(data []byte) (FuncDescriptor, error) {
+	var rv FuncDescriptor
+	r := bytes.NewReader(data)
+	getUvarint := func() (int, error) {
+		n, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == nil && n > uint64(r.Len()) {
+			// Every count and length is followed by at least that many bytes.
+			err = io.ErrUnexpectedEOF
+		}
+		return int(n), err
+	}
+	getString := func() (string, error) {
+		n, err := getUvarint()
+		if err != nil {
+			return "", err
+		}
+		b := make([]byte, n)
+		_, err = io.ReadFull(r, b)
+		return string(b), err
+	}
+	//
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return rv, io.ErrUnexpectedEOF
+	} else if header[0] != descriptorVersion {
+		return rv, fmt.Errorf("%w: descriptor version %v", ErrIncompatible, header[0])
+	}
+	rv.Variadic = header[1]&descriptorVariadic != 0
+	//
+	numIn, err := getUvarint()
+	if err != nil {
+		return FuncDescriptor{}, err
+	}
+	rv.InTypes, rv.InKinds = make([]string, numIn), make([]reflect.Kind, numIn)
+	for k := 0; k < numIn; k++ {
+		kind, err := binary.ReadUvarint(r)
+		if err != nil {
+			return FuncDescriptor{}, io.ErrUnexpectedEOF
+		}
+		rv.InKinds[k] = reflect.Kind(kind)
+		if rv.InTypes[k], err = getString(); err != nil {
+			return FuncDescriptor{}, err
+		}
+	}
+	numOut, err := getUvarint()
+	if err != nil {
+		return FuncDescriptor{}, err
+	}
+	rv.OutTypes = make([]string, numOut)
+	for k := 0; k < numOut; k++ {
+		if rv.OutTypes[k], err = getString(); err != nil {
+			return FuncDescriptor{}, err
+		}
+	}
+	return rv, nil
+}
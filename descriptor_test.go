@@ -0,0 +1,56 @@
+package call_test
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleDecodeFuncDescriptor() {
+	fn := func(name string, sess examples.Session, tags ...string) (int, error) {
+		return 0, nil
+	}
+
+	data := call.StatFunc(fn).Encode()
+	// data is sent to a peer that does not have fn.
+	desc, err := call.DecodeFuncDescriptor(data)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(desc.InTypes, desc.InKinds, desc.OutTypes, desc.Variadic)
+
+	// Output: [string examples.Session []string] [string interface slice] [int error] true
+}
+
+func TestDecodeFuncDescriptor(t *testing.T) {
+	chk := assert.New(t)
+	//
+	desc, err := call.DecodeFuncDescriptor(call.StatFunc(func() {}).Encode())
+	chk.NoError(err)
+	chk.Equal(call.FuncDescriptor{InTypes: []string{}, InKinds: []reflect.Kind{}, OutTypes: []string{}}, desc)
+	//
+	m, err := call.Stat(&examples.Counter{}).Methods.Named("Count")
+	chk.NoError(err)
+	data := m.Encode()
+	desc, err = call.DecodeFuncDescriptor(data)
+	chk.NoError(err)
+	chk.Equal([]string{"*examples.Counter"}, desc.InTypes)
+	chk.Equal([]string{"int"}, desc.OutTypes)
+	chk.False(desc.Variadic)
+	// Every truncation is an error.
+	for k := 0; k < len(data); k++ {
+		_, err = call.DecodeFuncDescriptor(data[:k])
+		chk.ErrorIs(err, io.ErrUnexpectedEOF, "length %v", k)
+	}
+	// Unknown version.
+	bad := append([]byte{99}, data[1:]...)
+	_, err = call.DecodeFuncDescriptor(bad)
+	chk.ErrorIs(err, call.ErrIncompatible)
+}
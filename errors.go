@@ -1,7 +1,100 @@
 package call
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrNotFound = fmt.Errorf("not found")
+
+	// ErrIncompatible is returned when a value is not compatible with the receiver,
+	// function, or argument it is being applied to.
+	ErrIncompatible = fmt.Errorf("incompatible")
+
+	// ErrAmbiguous is returned when a provider is registered for arguments that already
+	// have one.
+	ErrAmbiguous = fmt.Errorf("ambiguous")
 )
+
+// ArgError is an error associated with the argument at index N.
+type ArgError struct {
+	// N is the argument index.
+	N int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the argument index and the underlying error as a string.
+func (e *ArgError) Error() string {
+	return fmt.Sprintf("argument %v: %v", e.N, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ArgError) Unwrap() error {
+	return e.Err
+}
+
+// Errors is a list of errors that is itself an error.
+//
+// errors.Is and errors.As consider every error in the list.
+type Errors []error
+
+// Error returns the errors in the list joined by "; ".
+func (e Errors) Error() string {
+	strs := make([]string, len(e))
+	for k, err := range e {
+		strs[k] = err.Error()
+	}
+	return strings.Join(strs, "; ")
+}
+
+// Is returns true if any error in the list matches target.
+func (e Errors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error in the list that matches target.
+func (e Errors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorCode maps a sentinel error to a code; see Func.MapError.
+type errorCode struct {
+	sentinel error
+	code     int
+}
+
+// MapError registers code for errors returned by the function that match sentinel with
+// errors.Is; Call() sets Result.Code to the code of the first registered sentinel that the
+// returned error matches.  Gateways can map handler errors to response codes uniformly instead
+// of inspecting Result.Error at every call site.
+//
+// Sentinels are consulted in the order they were registered and the first match wins; register
+// more specific errors before errors they wrap.  Errors that prevent the function from being
+// called, such as those from an initializer, are not mapped.
+func (f *Func) MapError(sentinel error, code int) {
+	f.mutable()
+	f.errorCodes = append(cloned(f.errorCodes).([]errorCode), errorCode{sentinel: sentinel, code: code})
+}
+
+// errorCode returns the code of the first sentinel matched by err or zero.
+func (f *Func) errorCode(err error) int {
+	for _, ec := range f.errorCodes {
+		if errors.Is(err, ec.sentinel) {
+			return ec.code
+		}
+	}
+	return 0
+}
@@ -96,3 +96,19 @@ type ManyArgs struct{}
 // resulting performance.
 func (m ManyArgs) Many(r Response, req *Request, sess Session, a, b, c *Request) {
 }
+
+// Counter has a value-receiver method to read the count and a pointer-receiver method to
+// increment it.
+type Counter struct {
+	N int
+}
+
+// Count returns the current count.
+func (c Counter) Count() int {
+	return c.N
+}
+
+// Incr increments the count.
+func (c *Counter) Incr() {
+	c.N++
+}
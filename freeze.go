@@ -0,0 +1,37 @@
+package call
+
+// Freeze returns a frozen copy of the Instance for sharing between goroutines once it is
+// configured.  Args, Call, and the other calling and introspection methods remain available
+// but methods that change the configuration panic, including Rebind, RebindValue,
+// RebindPointer, Override, SetInit, and ClassifyMethods on the Instance and PruneIn,
+// SetDefault, SetArgPool, SetNamedProvider, and the other setters on the *Func of each Method.
+// Use Method.CallWith to call a method on a different receiver instead of Rebind.
+//
+// SetSingleThreaded is turned off for the methods of the frozen copy since they are shared
+// between goroutines.
+//
+// The exported fields of Instance and Func, such as Methods, InCreate, and OnMissingArg, can
+// not be guarded and must not be modified.  Copy returns an unfrozen copy of a frozen Instance.
+func (m *Instance) Freeze() *Instance {
+	rv := m.Copy()
+	rv.frozen = true
+	for k := range rv.Methods {
+		f := rv.Methods[k].Func
+		f.frozen, f.singleThreaded = true, false
+	}
+	return rv
+}
+
+// mutable panics if the Instance is frozen; see Freeze.
+func (m *Instance) mutable() {
+	if m.frozen {
+		panic("call: Instance of " + m.receiverType.String() + " is frozen; see Instance.Freeze")
+	}
+}
+
+// mutable panics if the Func belongs to a frozen Instance; see Instance.Freeze.
+func (f *Func) mutable() {
+	if f.frozen {
+		panic("call: " + f.Pretty() + " is frozen; see Instance.Freeze")
+	}
+}
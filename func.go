@@ -3,14 +3,56 @@ package call
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 var (
 	// zeroReflectValue is a global re-usable instance of a zero reflect.Value
 	zeroReflectValue reflect.Value
+
+	// errorType is the reflect.Type of the error interface.
+	errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+	// zeroInterfaces maps an interface reflect.Type to its shared I(nil) reflect.Value.
+	zeroInterfaces = &sync.Map{}
 )
 
+// zeroInterface returns the shared I(nil) reflect.Value for the interface type T.
+//
+// The returned values are placed into InCache and are never modified by this package;
+// therefore a single value per interface type is shared by all Func instances.
+func zeroInterface(T reflect.Type) reflect.Value {
+	if rv, ok := zeroInterfaces.Load(T); ok {
+		return rv.(reflect.Value)
+	}
+	rv, _ := zeroInterfaces.LoadOrStore(T, reflect.Indirect(reflect.New(T)))
+	return rv.(reflect.Value)
+}
+
+// cloned returns a shallow copy of v, which must be a slice or a map; a nil slice is returned
+// as an empty slice and a nil map as an empty map.
+//
+// The slices and maps configuring a Func are shared with copies of the Func, such as those
+// made by Instance.Copy, and with snapshots returned by SaveConfig.  Therefore methods that
+// change the configuration never modify them in place; they modify the result of cloned, or
+// build new ones, and replace them.
+func cloned(v interface{}) interface{} {
+	V := reflect.ValueOf(v)
+	if V.Kind() == reflect.Map {
+		rv := reflect.MakeMapWithSize(V.Type(), V.Len())
+		for iter := V.MapRange(); iter.Next(); {
+			rv.SetMapIndex(iter.Key(), iter.Value())
+		}
+		return rv.Interface()
+	}
+	rv := reflect.MakeSlice(V.Type(), V.Len(), V.Len())
+	reflect.Copy(rv, V)
+	return rv.Interface()
+}
+
 // Func represents a single function call and facilitates creating arguments
 // for the func as well as invoking it.
 type Func struct {
@@ -49,12 +91,76 @@ type Func struct {
 	// Therefore such values are stored in InCache and returned in calls to Args as appropriate.
 	InCache []Arg
 
+	// IsVariadic is true if the function is variadic; its final argument is then a slice of
+	// VariadicElem.  Args() creates the slice as nil and Call() passes Values[NumIn-1] as the
+	// whole variadic list, as with reflect.Value.CallSlice; assign a slice to the argument or
+	// append to it through Pointers to pass any number of elements.
+	IsVariadic   bool
+	VariadicElem reflect.Type
+
 	// NumOut is the length of the OutTypes slice.
 	NumOut int
 	// OutTypes is the type-list of values returned by calling the function.
 	OutTypes []reflect.Type
+
+	// OnMissingArg, if not nil, is called during Call() for each argument whose value is the
+	// zero reflect.Value, such as an argument removed by PruneIn that the caller did not
+	// provide.  It allows dependencies that are unknown when Args() is called to be resolved
+	// lazily.
+	//
+	// arg has only N and T set.  If OnMissingArg returns false or a value that is not
+	// assignable to arg.T then the function is not called and Result.Error is an *ArgError
+	// wrapping ErrNotFound or ErrIncompatible.  When OnMissingArg is nil a missing argument
+	// causes a panic in package reflect.
+	OnMissingArg func(arg Arg) (reflect.Value, bool)
+
+	// OnCreateArg, if not nil, is called by Args() and ArgsIf() for each argument in InCreate
+	// after it is created, in the order of InCreate.  v is the new addressable value after
+	// any default or pooled value has been assigned; OnCreateArg may modify it, for example
+	// to initialize structs with defaults, or record it for profiling.
+	//
+	// OnCreateArg is not called by ArgsValues, which does not create arguments.
+	OnCreateArg func(arg Arg, v reflect.Value)
+
+	// errorSlots[k] is true if OutTypes[k] implements error; see Result.ErrorSlots.
+	errorSlots []bool
+	// middleware wraps Call; see Middleware.
+	middleware []Middleware
+	// argNames[k] is the registered name of argument k; see SetArgNames and SetArgName.
+	argNames []string
+	// singleThreaded is set by SetSingleThreaded; owned is the *Args reused by Args().
+	singleThreaded bool
+	owned          *Args
+	// hot is the private argument pool set by SetHot.
+	hot *sync.Pool
+	// initOf is the Instance whose initializer runs before the call; see Instance.SetInit.
+	initOf *Instance
+	// errorCodes are consulted in order to set Result.Code; see MapError.
+	errorCodes []errorCode
+	// transforms are run in order by Args.Apply; see AddArgTransform.
+	transforms []argTransform
+	// timed is set by TimeCalls.
+	timed bool
+	// providers are the named providers set by SetNamedProvider.
+	providers map[string]func() reflect.Value
+	// bindings are the providers of interface arguments by argument index; see BindInterface.
+	bindings map[int]func() reflect.Value
+	// zeroPooled is set by SetZeroPooled.
+	zeroPooled bool
+	// readOnly is set by Method.SetReadOnly.
+	readOnly bool
+	// frozen is set by Instance.Freeze.
+	frozen bool
 }
 
+// Middleware wraps the invocation performed by Func.Call.  A Middleware receives next, which
+// performs the remainder of the call, and returns a function that is called in its place.
+//
+// A Middleware may inspect or modify the *Args before calling next and inspect or modify
+// the Result afterwards.  If a Middleware does not call next then the *Args are not
+// returned to the argument pool.
+type Middleware func(next func(*Args) Result) func(*Args) Result
+
 // StatFunc accepts an arbitrary function and returns an associated Func.
 func StatFunc(f interface{}) *Func {
 	T := reflect.TypeOf(f)
@@ -62,6 +168,31 @@ func StatFunc(f interface{}) *Func {
 	return newFunc(F, T)
 }
 
+// StatFuncMap calls StatFunc for each value in m and returns the results with the same keys;
+// it is intended for plugin registries of handlers or method expressions such as (*T).Method,
+// whose first argument is the receiver.
+//
+// An error wrapping ErrIncompatible that names the key is returned if a value is nil or is not
+// a function; keys are checked in sorted order and no map is returned in that case.
+func StatFuncMap(m map[string]interface{}) (map[string]*Func, error) {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rv := make(map[string]*Func, len(m))
+	for _, key := range keys {
+		F := reflect.ValueOf(m[key])
+		if !F.IsValid() || F.Kind() != reflect.Func {
+			return nil, fmt.Errorf("%w: %v: %T is not a function", ErrIncompatible, key, m[key])
+		} else if F.IsNil() {
+			return nil, fmt.Errorf("%w: %v: %T is nil", ErrIncompatible, key, m[key])
+		}
+		rv[key] = newFunc(F, F.Type())
+	}
+	return rv, nil
+}
+
 // newFunc creates a Func struct from the given reflect type which must represent a function
 // or a panic occurs.
 func newFunc(F reflect.Value, T reflect.Type) *Func {
@@ -79,25 +210,34 @@ func newFunc(F reflect.Value, T reflect.Type) *Func {
 		//
 		// Certain types+kinds are stored in the InCache member of Func.
 		if inKinds[k] == reflect.Interface {
-			inCache = append(inCache, Arg{N: k, T: in, V: reflect.Indirect(reflect.New(in))})
+			inCache = append(inCache, Arg{N: k, T: in, V: zeroInterface(in)})
 		} else {
 			inCreate = append(inCreate, Arg{N: k, T: in})
 		}
 	}
+	var variadicElem reflect.Type
+	if T.IsVariadic() {
+		variadicElem = inTypes[numIn-1].Elem()
+	}
+	errorSlots := make([]bool, numOut)
 	for k := 0; k < numOut; k++ {
 		out := T.Out(k)
 		outTypes[k] = out
+		errorSlots[k] = out.Implements(errorType)
 	}
 	//
 	return &Func{
-		Func:     F,
-		NumIn:    numIn,
-		InCache:  inCache,
-		InCreate: inCreate,
-		InKinds:  inKinds,
-		InTypes:  inTypes,
-		NumOut:   numOut,
-		OutTypes: outTypes,
+		Func:         F,
+		NumIn:        numIn,
+		InCache:      inCache,
+		InCreate:     inCreate,
+		InKinds:      inKinds,
+		InTypes:      inTypes,
+		IsVariadic:   T.IsVariadic(),
+		VariadicElem: variadicElem,
+		NumOut:       numOut,
+		OutTypes:     outTypes,
+		errorSlots:   errorSlots,
 	}
 }
 
@@ -123,20 +263,410 @@ func newFunc(F reflect.Value, T reflect.Type) *Func {
 //	//     2. You are unmarshaling into a type whose Pointers entry is nil, such as
 //	//        an interface `type I interface {...}`
 func (f *Func) Args() *Args {
-	var V reflect.Value
-	rv := argPool.Get().(*Args)
-	rv.Reset(f.NumIn)
-	rv.Values, rv.Pointers = rv.Values[:f.NumIn], rv.Pointers[:f.NumIn]
+	rv := f.getArgs()
+	for _, arg := range f.InCreate {
+		f.create(rv, arg)
+	}
+	for _, arg := range f.InCache {
+		rv.Values[arg.N], rv.Pointers[arg.N] = f.cached(arg), nil
+	}
+	return rv
+}
+
+// create creates the argument in InCreate described by arg and stores it in args.
+func (f *Func) create(args *Args, arg Arg) {
+	countArg(arg.T)
+	V := reflect.New(arg.T)
+	if provide := f.provider(arg.N); provide != nil {
+		V.Elem().Set(provide())
+	} else if provide := f.bindings[arg.N]; provide != nil {
+		V.Elem().Set(provide())
+	} else if arg.pool != nil {
+		V.Elem().Set(arg.pool.get())
+		if f.zeroPooled {
+			zeroStruct(V.Elem())
+		}
+		args.pooled = append(args.pooled, arg)
+	} else if arg.V.IsValid() {
+		V.Elem().Set(arg.V)
+	}
+	if f.OnCreateArg != nil {
+		f.OnCreateArg(arg, V.Elem())
+	}
+	args.Values[arg.N], args.Pointers[arg.N] = V.Elem(), V.Interface()
+}
+
+// cached returns the value of the argument in InCache described by arg.
+func (f *Func) cached(arg Arg) reflect.Value {
+	if provide := f.provider(arg.N); provide != nil {
+		return provide()
+	}
+	return arg.V
+}
+
+// ArgsIf is similar to Args() except only arguments for which pred returns true are
+// created or taken from InCache.  The Values and Pointers entries of other arguments are
+// left as the zero reflect.Value and nil respectively.
+//
+// ArgsIf is a per-call alternative to PruneIn; pred may consult any external state to decide
+// which arguments are needed.  Arguments skipped by pred must be supplied by the caller
+// before Call() otherwise Call() will panic.
+func (f *Func) ArgsIf(pred func(arg Arg) bool) *Args {
+	rv := f.getArgs()
 	for _, arg := range f.InCreate {
-		V = reflect.New(arg.T)
-		rv.Values[arg.N], rv.Pointers[arg.N] = V.Elem(), V.Interface()
+		if pred(arg) {
+			f.create(rv, arg)
+		}
 	}
 	for _, arg := range f.InCache {
-		rv.Values[arg.N], rv.Pointers[arg.N] = arg.V, nil
+		if pred(arg) {
+			rv.Values[arg.N] = f.cached(arg)
+		}
 	}
 	return rv
 }
 
+// SetSingleThreaded sets whether the Func is only used by one goroutine at a time.  When on
+// is true Args() returns the same *Args on every call instead of taking one from the argument
+// pool; this avoids the cost of the pool on every call.
+//
+// A single-threaded Func must not be used concurrently and the *Args returned by Args() must
+// be passed to Call() before Args() is called again.  The *Args is not shared with copies of
+// the Func such as those made by Instance.Copy.
+func (f *Func) SetSingleThreaded(on bool) {
+	f.mutable()
+	f.singleThreaded, f.owned = on, nil
+}
+
+// SetHot gives the Func a private argument pool used by Args() and Call() instead of the
+// shared argument pool; the *Args in the private pool are sized for the function.  poolSize
+// *Args are allocated into the private pool immediately.
+//
+// SetHot is intended for the few functions that dominate traffic; other functions are well
+// served by the shared pool.  The private pool is shared with copies of the Func, such as
+// those made by Instance.Copy, since they have the same arguments.
+func (f *Func) SetHot(poolSize int) {
+	f.mutable()
+	numIn := f.NumIn
+	pool := &sync.Pool{}
+	pool.New = func() interface{} {
+		rv := allocArgs(numIn)
+		rv.pool = pool
+		return rv
+	}
+	for k := 0; k < poolSize; k++ {
+		pool.Put(pool.New())
+	}
+	f.hot = pool
+}
+
+// ArgsValues is similar to Args() except only Values is populated; Pointers is empty.  It is
+// intended for callers that never decode into arguments and assign Values directly.
+//
+// Arguments are resolved as they are by Args(): from a named provider, an interface binding,
+// an argument pool, a default, or else the zero value of their type.  Replace elements of
+// Values rather than modifying them with reflect.Value.Set.  ArgsValues avoids allocating a
+// new value and boxing a pointer for every argument.
+//
+// Helpers that decode into Pointers, such as DecodeEnv or SetText, return an error wrapping
+// ErrNotFound for such an *Args.
+func (f *Func) ArgsValues() *Args {
+	rv := f.getArgs()
+	rv.Pointers = rv.Pointers[:0]
+	for _, arg := range f.InCreate {
+		if provide := f.provider(arg.N); provide != nil {
+			rv.Values[arg.N] = provide()
+		} else if provide := f.bindings[arg.N]; provide != nil {
+			rv.Values[arg.N] = provide()
+		} else if arg.pool != nil {
+			V := arg.pool.get()
+			if f.zeroPooled {
+				// zeroStruct requires a settable value.
+				settable := reflect.New(arg.T).Elem()
+				settable.Set(V)
+				zeroStruct(settable)
+				V = settable
+			}
+			rv.Values[arg.N] = V
+			rv.pooled = append(rv.pooled, arg)
+		} else if arg.V.IsValid() {
+			rv.Values[arg.N] = arg.V
+		} else {
+			rv.Values[arg.N] = reflect.Zero(arg.T)
+		}
+	}
+	for _, arg := range f.InCache {
+		rv.Values[arg.N] = f.cached(arg)
+	}
+	return rv
+}
+
+// getArgs returns an *Args from the pool with Values and Pointers sized for the function.
+func (f *Func) getArgs() *Args {
+	var rv *Args
+	if f.singleThreaded {
+		if f.owned == nil {
+			f.owned = allocArgs(f.NumIn)
+			f.owned.owned = true
+		}
+		rv = f.owned
+	} else if f.hot != nil {
+		rv = f.hot.Get().(*Args)
+	} else {
+		rv = argPool.Get().(*Args)
+	}
+	if Debug {
+		f.debugGrow(rv)
+	}
+	return sized(rv, f.NumIn)
+}
+
+// SetDefault sets the default value of the argument at index; Args() initializes the
+// argument to v instead of its zero value.  For a Method the receiver is index 0 and can not
+// have a default.
+//
+// v must be assignable to InTypes[index] or an error wrapping ErrIncompatible is returned.
+// An error wrapping ErrNotFound is returned if index is out of range or the argument has
+// been removed by PruneIn.
+//
+// Arguments are initialized by assignment; defaults that are slices, maps, or pointers share
+// their underlying data with every call.
+func (f *Func) SetDefault(index int, v interface{}) error {
+	f.mutable()
+	if index < 0 || index >= f.NumIn {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	}
+	V, err := valueOf(v, f.InTypes[index])
+	if err != nil {
+		return fmt.Errorf("argument %v: %w", index, err)
+	}
+	D := reflect.New(f.InTypes[index]).Elem()
+	D.Set(V)
+	//
+	set := func(slice []Arg) []Arg {
+		for k, arg := range slice {
+			if arg.N == index {
+				slice = cloned(slice).([]Arg)
+				slice[k].V = D
+				return slice
+			}
+		}
+		return nil
+	}
+	if slice := set(f.InCreate); slice != nil {
+		f.InCreate = slice
+	} else if slice = set(f.InCache); slice != nil {
+		f.InCache = slice
+	} else {
+		return fmt.Errorf("%w: argument %v of %v is not created by Args()", ErrNotFound, index, f.Pretty())
+	}
+	return nil
+}
+
+// MarkImmutable moves the argument at index from InCreate to InCache with the value v; Args()
+// then returns v for the argument on every call and its Pointers entry is nil.  It is the
+// concrete-type analog of the caching performed for interface arguments.
+//
+// MarkImmutable is an opt-in optimization for arguments, such as a large configuration struct,
+// that the function only reads.  It is unsafe if the function modifies the argument through
+// a reference type such as a map, slice, or pointer since every call shares v.
+//
+// v must be assignable to InTypes[index] or an error wrapping ErrIncompatible is returned.  An
+// error wrapping ErrNotFound is returned if the argument is not in InCreate, such as the
+// receiver of a Method or an argument removed by PruneIn.
+func (f *Func) MarkImmutable(index int, v reflect.Value) error {
+	f.mutable()
+	k := -1
+	for n, arg := range f.InCreate {
+		if arg.N == index {
+			k = n
+			break
+		}
+	}
+	if k == -1 {
+		return fmt.Errorf("%w: %v has no created argument %v", ErrNotFound, f.Pretty(), index)
+	} else if !v.IsValid() {
+		return fmt.Errorf("%w: argument %v: invalid value", ErrIncompatible, index)
+	}
+	V, err := adapt(v, f.InTypes[index])
+	if err != nil {
+		return fmt.Errorf("argument %v: %w", index, err)
+	}
+	//
+	arg, inCreate := f.InCreate[k], cloned(f.InCreate).([]Arg)
+	arg.V, arg.pool = V, nil
+	f.InCreate = append(inCreate[:k], inCreate[k+1:]...)
+	f.InCache = append(cloned(f.InCache).([]Arg), arg)
+	if f.bindings[index] != nil {
+		f.bind(map[int]func() reflect.Value{index: nil})
+	}
+	return nil
+}
+
+// SetNamedProvider registers fn to provide the value of every argument named name with
+// SetArgName or SetArgNames; Args() and ArgsIf() call fn for each such argument.  Named
+// providers distinguish dependencies of the same type, such as read and write *sql.DB
+// connections, that can not be told apart by type alone.
+//
+// A named provider is consulted before the pool set by SetArgPool and the default set by
+// SetDefault.  fn must return a value assignable to the type of each argument with the name;
+// for arguments in InCreate the value is assigned to a new addressable value and Pointers
+// remains available.  Arguments removed by PruneIn are not affected.  A nil fn removes the
+// provider.
+//
+// An error wrapping ErrAmbiguous is returned if name already has a provider; remove it first
+// to replace it.
+func (f *Func) SetNamedProvider(name string, fn func() reflect.Value) error {
+	f.mutable()
+	if fn != nil && f.providers[name] != nil {
+		return fmt.Errorf("%w: %v already has a provider named %v", ErrAmbiguous, f.Pretty(), name)
+	}
+	providers := cloned(f.providers).(map[string]func() reflect.Value)
+	if fn == nil {
+		delete(providers, name)
+	} else {
+		providers[name] = fn
+	}
+	if len(providers) == 0 {
+		providers = nil
+	}
+	f.providers = providers
+	return nil
+}
+
+// provider returns the named provider of the argument at index or nil.
+func (f *Func) provider(index int) func() reflect.Value {
+	if f.providers == nil || index >= len(f.argNames) || f.argNames[index] == "" {
+		return nil
+	}
+	return f.providers[f.argNames[index]]
+}
+
+// SetArgPool registers an application pool for arguments of type T; Args() initializes each
+// argument of type T with the value returned by get instead of its zero value.  When the
+// *Args are returned to the argument pool, during Call() for example, put is called with the
+// value in each such argument.  put may be nil.
+//
+// get must return a value assignable to T.  Typically T is a pointer type such as *Request
+// and get and put wrap a sync.Pool of *Request.
+//
+// SetArgPool affects arguments of type T in InCreate; arguments removed by PruneIn are not
+// affected.  A pool takes precedence over a default set by SetDefault.  See SetZeroPooled for
+// clearing recycled values.
+func (f *Func) SetArgPool(T reflect.Type, get func() reflect.Value, put func(reflect.Value)) {
+	f.mutable()
+	pool := &typePool{get: get, put: put}
+	inCreate := cloned(f.InCreate).([]Arg)
+	for k := range inCreate {
+		if inCreate[k].T == T {
+			inCreate[k].pool = pool
+		}
+	}
+	f.InCreate = inCreate
+}
+
+// BindInterface registers provider to create the value of every argument of the interface type
+// iface; Args() and ArgsIf() call provider for each such argument instead of using the shared
+// I(nil) from InCache.  For example provider may return a new *MySession for every argument of
+// type Session.
+//
+// Bound arguments are moved from InCache to InCreate: each call to Args() assigns the value
+// returned by provider to a new addressable I and its Pointers entry is a *I.  provider must
+// return a value that implements iface.  A named provider set with SetNamedProvider takes
+// precedence over a binding and a binding takes precedence over a default set by SetDefault.
+//
+// Arguments removed by PruneIn are not affected.  A nil provider removes the binding and
+// returns the arguments to InCache.
+//
+// An error wrapping ErrAmbiguous is returned if iface is already bound; remove the binding
+// first to replace it.  An error wrapping ErrIncompatible is returned if iface is not an
+// interface.
+func (f *Func) BindInterface(iface reflect.Type, provider func() reflect.Value) error {
+	f.mutable()
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return fmt.Errorf("%w: %v is not an interface", ErrIncompatible, iface)
+	}
+	for n := range f.bindings {
+		if provider != nil && f.InTypes[n] == iface {
+			return fmt.Errorf("%w: %v already binds %v", ErrAmbiguous, f.Pretty(), iface)
+		}
+	}
+	changes := map[int]func() reflect.Value{}
+	inCreate := make([]Arg, 0, len(f.InCreate)+len(f.InCache))
+	inCache := make([]Arg, 0, len(f.InCache))
+	for _, arg := range f.InCreate {
+		if arg.T == iface {
+			changes[arg.N] = provider
+		}
+		if arg.T == iface && provider == nil {
+			if !arg.V.IsValid() {
+				arg.V = zeroInterface(iface)
+			}
+			inCache = append(inCache, arg)
+			continue
+		}
+		inCreate = append(inCreate, arg)
+	}
+	for _, arg := range f.InCache {
+		if arg.T == iface && provider != nil {
+			changes[arg.N] = provider
+			inCreate = append(inCreate, arg)
+			continue
+		}
+		inCache = append(inCache, arg)
+	}
+	sort.Slice(inCreate, func(i, j int) bool {
+		return inCreate[i].N < inCreate[j].N
+	})
+	f.InCreate, f.InCache = inCreate, inCache
+	f.bind(changes)
+	return nil
+}
+
+// bind applies changes to the bindings of BindInterface; a nil provider removes the binding.
+func (f *Func) bind(changes map[int]func() reflect.Value) {
+	bindings := cloned(f.bindings).(map[int]func() reflect.Value)
+	for k, v := range changes {
+		if v == nil {
+			delete(bindings, k)
+		} else {
+			bindings[k] = v
+		}
+	}
+	if len(bindings) == 0 {
+		bindings = nil
+	}
+	f.bindings = bindings
+}
+
+// SetZeroPooled sets whether Args() zeroes the values drawn from pools registered with
+// SetArgPool before handing them out; it guarantees that data from a previous call does not
+// reach the next one when the pool's put function, or a decoder that sets only some fields,
+// leaves recycled values dirty.
+//
+// Struct values and the structs referenced by non-nil pointer-to-struct values are zeroed in
+// their entirety, including nested structs; pointers, slices, and maps within them become nil.
+// Values of other types are not changed.  The pointer itself is kept so the recycled struct
+// is still reused.
+func (f *Func) SetZeroPooled(on bool) {
+	f.mutable()
+	f.zeroPooled = on
+}
+
+// zeroStruct sets V, if it is a struct, or the struct V points to, if V is a non-nil
+// pointer-to-struct, to its zero value.  V must be settable.
+func zeroStruct(V reflect.Value) {
+	if V.Kind() == reflect.Ptr {
+		if V.IsNil() {
+			return
+		}
+		V = V.Elem()
+	}
+	if V.Kind() == reflect.Struct {
+		V.Set(reflect.Zero(V.Type()))
+	}
+}
+
 // Call invokes the function described by Func; call Args() to obtain the arguments.
 //	f := Stat(SomeFunc)
 //	args := f.Args()
@@ -146,17 +676,375 @@ func (f *Func) Args() *Args {
 //
 // During Call() the args are returned to the argument pool (see Args()).
 func (f *Func) Call(args *Args) Result {
-	var iface interface{}
-	var result Result
+	if f.initOf != nil {
+		if err := f.initOf.runInit(); err != nil {
+			putArgs(args)
+			return Result{Error: err}
+		}
+	}
+	if len(f.middleware) == 0 {
+		return f.call(args)
+	}
+	next := f.call
+	for k := len(f.middleware) - 1; k >= 0; k-- {
+		next = f.middleware[k](next)
+	}
+	return next(args)
+}
+
+// direct returns true if Call() does nothing other than call the function; call paths that
+// avoid creating a Result may bypass Call() when direct is true.
+func (f *Func) direct() bool {
+	return len(f.middleware) == 0 && f.initOf == nil && f.OnMissingArg == nil && !f.timed
+}
+
+// call invokes the function and returns args to the pool.
+func (f *Func) call(args *Args) Result {
+	defer putArgs(args)
 	//
-	defer func() {
-		for k, max := 0, len(args.Values); k < max; k++ {
-			args.Values[k], args.Pointers[k] = zeroReflectValue, nil
+	if f.OnMissingArg != nil {
+		if err := f.resolveMissing(args); err != nil {
+			return Result{Error: err}
+		}
+	}
+	if f.timed {
+		start := time.Now()
+		returns := f.invoke(args.Values)
+		elapsed := time.Since(start)
+		result := f.result(returns)
+		result.Duration = elapsed
+		return result
+	}
+	return f.result(f.invoke(args.Values))
+}
+
+// invoke calls the function with values; the final value of a variadic function is the
+// variadic slice.
+func (f *Func) invoke(values []reflect.Value) []reflect.Value {
+	if f.IsVariadic {
+		return f.Func.CallSlice(values)
+	}
+	return f.Func.Call(values)
+}
+
+// TimeCalls sets whether Call() measures the time spent in the function and stores it in
+// Result.Duration; it is off by default so untimed calls do not pay for reading the clock.
+//
+// Only the execution of the function itself is measured; Args(), decoding arguments, an
+// initializer, and middleware are not included.
+func (f *Func) TimeCalls(on bool) {
+	f.mutable()
+	f.timed = on
+}
+
+// resolveMissing replaces invalid values in args with values from OnMissingArg.
+func (f *Func) resolveMissing(args *Args) error {
+	for n, V := range args.Values {
+		if V.IsValid() || n >= f.NumIn {
+			continue
+		}
+		arg := Arg{N: n, T: f.InTypes[n]}
+		V, ok := f.OnMissingArg(arg)
+		if !ok || !V.IsValid() {
+			return &ArgError{N: n, Err: fmt.Errorf("%w: no value for %v", ErrNotFound, arg.T)}
+		}
+		V, err := adapt(V, arg.T)
+		if err != nil {
+			return &ArgError{N: n, Err: err}
+		}
+		args.Values[n] = V
+	}
+	return nil
+}
+
+// CallValues invokes the function with values as its leading arguments; values[k] is the
+// value of argument k.  values may be shorter than NumIn in which case the remaining arguments
+// are created by Args() and receive their default values (see SetDefault) or zero values.
+//
+// Each value must be assignable to its parameter type; nil is allowed for parameters that
+// can be nil.  A *T is dereferenced for a parameter of type T; a T is never addressable and
+// is not accepted for a parameter of type *T, see SetValue.  An error wrapping ErrIncompatible
+// is returned if there are too many values or a value does not match its parameter; the
+// function is not called in that case.
+func (f *Func) CallValues(values ...interface{}) (Result, error) {
+	return f.callValues(f.Args(), 0, values)
+}
+
+// callValues assigns values to args beginning at index offset and invokes the function;
+// args are returned to the pool if a value is not compatible.
+func (f *Func) callValues(args *Args, offset int, values []interface{}) (Result, error) {
+	if offset+len(values) > f.NumIn {
+		putArgs(args)
+		return Result{}, fmt.Errorf("%w: %v expects at most %v arguments; got %v", ErrIncompatible, f.Pretty(), f.NumIn-offset, len(values))
+	}
+	for k, v := range values {
+		V, err := valueOf(v, f.InTypes[offset+k])
+		if err != nil {
+			putArgs(args)
+			return Result{}, fmt.Errorf("argument %v: %w", offset+k, err)
+		}
+		f.set(args, offset+k, V)
+	}
+	// Arguments removed by PruneIn are not created by Args().
+	for k := offset + len(values); k < f.NumIn; k++ {
+		if !args.Values[k].IsValid() {
+			args.Values[k] = reflect.Zero(f.InTypes[k])
+		}
+	}
+	return f.Call(args), nil
+}
+
+// CallValuesSkipInterfaces is the same as CallValues except values are assigned only to the
+// arguments that are not interfaces; values[k] is the value of the k-th such argument.
+// Interface arguments, typically injected dependencies, keep the value given to them by Args()
+// such as a default from SetDefault or the nil interface.
+//
+// An error wrapping ErrIncompatible is returned if there are more values than non-interface
+// arguments or a value does not match its argument; the function is not called in that case.
+func (f *Func) CallValuesSkipInterfaces(values ...interface{}) (Result, error) {
+	return f.callValuesSkipInterfaces(f.Args(), 0, values)
+}
+
+// callValuesSkipInterfaces is the implementation of CallValuesSkipInterfaces; arguments before
+// offset are not assigned.
+func (f *Func) callValuesSkipInterfaces(args *Args, offset int, values []interface{}) (Result, error) {
+	k := 0
+	for n := offset; n < f.NumIn; n++ {
+		if f.InKinds[n] != reflect.Interface && k < len(values) {
+			V, err := valueOf(values[k], f.InTypes[n])
+			if err != nil {
+				putArgs(args)
+				return Result{}, fmt.Errorf("argument %v: %w", n, err)
+			}
+			f.set(args, n, V)
+			k++
+		} else if !args.Values[n].IsValid() {
+			// Arguments removed by PruneIn are not created by Args().
+			args.Values[n] = reflect.Zero(f.InTypes[n])
 		}
-		argPool.Put(args)
-	}()
+	}
+	if k < len(values) {
+		putArgs(args)
+		return Result{}, fmt.Errorf("%w: %v expects at most %v non-interface arguments; got %v", ErrIncompatible, f.Pretty(), k, len(values))
+	}
+	return f.Call(args), nil
+}
+
+// Set assigns v to the argument at index in args and clears its entry in Pointers; decode
+// helpers such as SetText no longer apply to the argument.
+//
+// v is converted with the same rules as CallValues: nil is allowed for parameters that can be
+// nil and a *T is dereferenced for a parameter of type T.  An error wrapping ErrIncompatible is
+// returned if v does not match the parameter; an error wrapping ErrNotFound is returned if
+// index is out of range.
+func (f *Func) Set(args *Args, index int, v interface{}) error {
+	if index < 0 || index >= f.NumIn || index >= len(args.Values) {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	}
+	V, err := valueOf(v, f.InTypes[index])
+	if err != nil {
+		return fmt.Errorf("argument %v: %w", index, err)
+	}
+	f.set(args, index, V)
+	return nil
+}
+
+// SetValue is the same as Set except V is a reflect.Value; in addition to the rules of Set an
+// addressable T, such as a struct field reached through a pointer, has its address taken for
+// a parameter of type *T.
+func (f *Func) SetValue(args *Args, index int, V reflect.Value) error {
+	if index < 0 || index >= f.NumIn || index >= len(args.Values) {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	} else if !V.IsValid() {
+		return f.Set(args, index, nil)
+	}
+	V, err := adapt(V, f.InTypes[index])
+	if err != nil {
+		return fmt.Errorf("argument %v: %w", index, err)
+	}
+	f.set(args, index, V)
+	return nil
+}
+
+// SetNil sets the argument at index in args to the nil value of its parameter type and clears
+// its entry in Pointers; it allows a nil pointer, interface, slice, map, channel, or func to be
+// passed explicitly.
+//
+// An error wrapping ErrNotFound is returned if index is out of range; an error wrapping
+// ErrIncompatible is returned if the parameter can not be nil.
+func (f *Func) SetNil(args *Args, index int) error {
+	if index < 0 || index >= f.NumIn || index >= len(args.Values) {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	}
+	T := f.InTypes[index]
+	if !nilable(T.Kind()) {
+		return fmt.Errorf("%w: argument %v: %v can not be nil", ErrIncompatible, index, T)
+	}
+	f.set(args, index, reflect.Zero(T))
+	return nil
+}
+
+// set assigns V to Values[index] and clears Pointers[index] if present.
+func (f *Func) set(args *Args, index int, V reflect.Value) {
+	args.Values[index] = V
+	if index < len(args.Pointers) {
+		args.Pointers[index] = nil
+	}
+}
+
+// CallSpread invokes a variadic function by spreading the slice variadic into the final
+// parameter; it is the equivalent of the Go syntax f(a, b, slice...).
+//
+// fixed contains the values for the non-variadic parameters and its length must be NumIn-1.
+// Each value must be assignable to its parameter type; nil is allowed for parameters that
+// can be nil.
+//
+// variadic must be nil or a slice whose elements are assignable to the variadic element
+// type.  Slices of interfaces, such as []interface{}, are checked element by element.
+//
+// CallSpread does not use Args() or the argument defaults; the values are passed through
+// Call() so middleware, initializers, error codes, and TimeCalls apply.  An error wrapping
+// ErrIncompatible is returned if the function is not variadic or the values do not match the
+// parameters; the function is not called in that case.
+func (f *Func) CallSpread(fixed []interface{}, variadic interface{}) (Result, error) {
+	return f.callSpread(nil, fixed, variadic)
+}
+
+// callSpread is the implementation of CallSpread; values contains any leading values
+// such as a method receiver.
+func (f *Func) callSpread(values []reflect.Value, fixed []interface{}, variadic interface{}) (Result, error) {
+	if !f.IsVariadic {
+		return Result{}, fmt.Errorf("%w: %v is not variadic", ErrIncompatible, f.Pretty())
+	} else if len(values)+len(fixed) != f.NumIn-1 {
+		return Result{}, fmt.Errorf("%w: %v expects %v fixed arguments; got %v", ErrIncompatible, f.Pretty(), f.NumIn-1-len(values), len(fixed))
+	}
+	args := f.getArgs()
+	args.Pointers = args.Pointers[:0]
+	n := copy(args.Values, values)
+	for _, v := range fixed {
+		V, err := valueOf(v, f.InTypes[n])
+		if err != nil {
+			putArgs(args)
+			return Result{}, fmt.Errorf("argument %v: %w", n, err)
+		}
+		args.Values[n] = V
+		n++
+	}
 	//
-	returns := f.Func.Call(args.Values)
+	S, err := spreadOf(variadic, f.InTypes[n])
+	if err != nil {
+		putArgs(args)
+		return Result{}, fmt.Errorf("argument %v: %w", n, err)
+	}
+	args.Values[n] = S
+	return f.Call(args), nil
+}
+
+// CallInto is the same as Call except the returned values are stored in result instead of
+// a new Result.  result is reset before the call and its Values capacity is reused; a caller
+// that owns a single Result can pass it to CallInto repeatedly without allocating a new
+// Values slice on each call.
+//
+// During CallInto() the args are returned to the argument pool (see Args()).
+func (f *Func) CallInto(args *Args, result *Result) {
+	result.Reset()
+	if f.direct() {
+		defer putArgs(args)
+		f.resultInto(f.invoke(args.Values), result)
+		return
+	}
+	rv := f.Call(args)
+	result.Error, result.ErrorSlots, result.Code, result.Duration = rv.Error, rv.ErrorSlots, rv.Code, rv.Duration
+	result.Values = append(result.Values, rv.Values...)
+}
+
+// CallStruct invokes the function and assigns its first struct return value to the variable
+// dst points to; the error returned by the function, if any, is returned.  CallStruct avoids
+// copying a large struct return value out of Result.Values.
+//
+// dst must be a non-nil pointer to a type the struct is assignable to.  An error wrapping
+// ErrNotFound is returned if the function does not return a struct and an error wrapping
+// ErrIncompatible is returned if dst is not compatible; the function is not called in
+// either case.
+//
+// As with Call() the args are returned to the argument pool.
+func (f *Func) CallStruct(args *Args, dst interface{}) error {
+	index := -1
+	for k, T := range f.OutTypes {
+		if T.Kind() == reflect.Struct {
+			index = k
+			break
+		}
+	}
+	D := reflect.ValueOf(dst)
+	if index == -1 {
+		putArgs(args)
+		return fmt.Errorf("%w: %v does not return a struct", ErrNotFound, f.Pretty())
+	} else if D.Kind() != reflect.Ptr || D.IsNil() || !f.OutTypes[index].AssignableTo(D.Type().Elem()) {
+		putArgs(args)
+		return fmt.Errorf("%w: %v can not be assigned to %T", ErrIncompatible, f.OutTypes[index], dst)
+	}
+	if !f.direct() {
+		result := f.Call(args)
+		if index < len(result.Values) && result.Values[index] != nil {
+			D.Elem().Set(reflect.ValueOf(result.Values[index]))
+		}
+		return result.Error
+	}
+	defer putArgs(args)
+	var err error
+	returns := f.invoke(args.Values)
+	for k, rv := range returns {
+		if f.errorSlots[k] && (!nilable(rv.Kind()) || !rv.IsNil()) {
+			err = rv.Interface().(error)
+		}
+	}
+	D.Elem().Set(returns[index])
+	return err
+}
+
+// CallFanOut invokes the function and sends each return value that is not an error to the
+// channel at the same position in dsts; the error returned by the function, if any, is
+// returned.  It is intended for a reflective pipeline stage feeding several consumers.
+//
+// len(dsts) must equal the number of non-error return values or an error wrapping
+// ErrIncompatible is returned and the function is not called.  Values are sent in order
+// and each send blocks until the channel accepts it.  Nothing is sent if the call returns
+// an error.
+//
+// As with Call() the args are returned to the argument pool.
+func (f *Func) CallFanOut(args *Args, dsts ...chan<- interface{}) error {
+	count := 0
+	for _, isError := range f.errorSlots {
+		if !isError {
+			count++
+		}
+	}
+	if len(dsts) != count {
+		putArgs(args)
+		return fmt.Errorf("%w: %v has %v non-error return values; got %v channels", ErrIncompatible, f.Pretty(), count, len(dsts))
+	}
+	result := f.Call(args)
+	if result.Error != nil {
+		return result.Error
+	}
+	for k, v := range result.Payload() {
+		dsts[k] <- v
+	}
+	return nil
+}
+
+// result creates the Result from the values returned by calling the function.
+func (f *Func) result(returns []reflect.Value) Result {
+	var result Result
+	f.resultInto(returns, &result)
+	return result
+}
+
+// resultInto appends the values returned by calling the function to result.
+func (f *Func) resultInto(returns []reflect.Value, result *Result) {
+	var iface interface{}
+	result.ErrorSlots = f.errorSlots
 	for _, rv := range returns {
 		iface = rv.Interface()
 		result.Values = append(result.Values, iface)
@@ -164,14 +1052,92 @@ func (f *Func) Call(args *Args) Result {
 			result.Error = err
 		}
 	}
-	//
-	return result
+	if result.Error != nil && len(f.errorCodes) > 0 {
+		result.Code = f.errorCode(result.Error)
+	}
+}
+
+// AllArgs returns the arguments in InCreate and InCache as a single slice ordered by
+// argument index.  Arguments removed by PruneIn are not included.
+//
+// For a Method the receiver is never in InCreate or InCache and therefore is not included.
+func (f *Func) AllArgs() []Arg {
+	rv := make([]Arg, 0, len(f.InCreate)+len(f.InCache))
+	rv = append(append(rv, f.InCreate...), f.InCache...)
+	sort.Slice(rv, func(i, j int) bool {
+		return rv[i].N < rv[j].N
+	})
+	return rv
+}
+
+// RisksNilPanic returns the arguments that Args() leaves as nil and that would likely cause a
+// panic in a handler that uses them without checking: maps, slices, channels, pointers,
+// functions, and interfaces.  Arguments with a non-nil default or an argument pool are not
+// returned; arguments removed by PruneIn are not returned.
+//
+// RisksNilPanic is advisory; it is intended for warnings when registering handlers whose
+// arguments must be supplied or initialized before Call().
+func (f *Func) RisksNilPanic() []Arg {
+	var rv []Arg
+	for _, arg := range f.AllArgs() {
+		if !nilable(arg.T.Kind()) || arg.pool != nil || f.bindings[arg.N] != nil || (arg.V.IsValid() && !arg.V.IsNil()) {
+			continue
+		}
+		rv = append(rv, arg)
+	}
+	return rv
+}
+
+// UnfilledInterfaces returns the interface arguments in args that are still the nil interface
+// or have no value, such as arguments removed by PruneIn that were not supplied; the returned
+// Arg values have only N and T set.
+//
+// UnfilledInterfaces is intended for dispatchers that reject a request with a clear error
+// before Call() rather than letting the function panic on a nil interface.
+func (f *Func) UnfilledInterfaces(args *Args) []Arg {
+	var rv []Arg
+	for n, T := range f.InTypes {
+		if T.Kind() != reflect.Interface || n >= len(args.Values) {
+			continue
+		}
+		if V := args.Values[n]; !V.IsValid() || (V.Kind() == reflect.Interface && V.IsNil()) {
+			rv = append(rv, Arg{N: n, T: T})
+		}
+	}
+	return rv
+}
+
+// SharesCachedArgs returns true if Args() returns any values from InCache.  Such values are
+// shared by every *Args created by the Func; see InCache.
+func (f *Func) SharesCachedArgs() bool {
+	return len(f.InCache) > 0
+}
+
+// InType returns the type of the argument at index i; ok is false if i is out of range.
+func (f *Func) InType(i int) (T reflect.Type, ok bool) {
+	if i < 0 || i >= len(f.InTypes) {
+		return nil, false
+	}
+	return f.InTypes[i], true
+}
+
+// OutType returns the type of the return value at index i; ok is false if i is out of range.
+func (f *Func) OutType(i int) (T reflect.Type, ok bool) {
+	if i < 0 || i >= len(f.OutTypes) {
+		return nil, false
+	}
+	return f.OutTypes[i], true
 }
 
 // Pretty returns a string representing the func( args... ) return-value(s).
 func (f *Func) Pretty() string {
+	return "func " + f.signature(0)
+}
+
+// signature returns the argument list beginning at argument offset and the return values.
+func (f *Func) signature(offset int) string {
 	var args, returns []string
-	for _, arg := range f.InTypes {
+	for _, arg := range f.InTypes[offset:] {
 		args = append(args, arg.String())
 	}
 	for _, rv := range f.OutTypes {
@@ -184,7 +1150,12 @@ func (f *Func) Pretty() string {
 	} else if f.NumOut > 1 {
 		ro, rc = " (", ")"
 	}
-	return fmt.Sprintf("func (%v)%v%v%v", argstr, ro, rvstr, rc)
+	return fmt.Sprintf("(%v)%v%v%v", argstr, ro, rvstr, rc)
+}
+
+// String returns Pretty(); it allows a *Func to be used directly with fmt verbs such as %v.
+func (f *Func) String() string {
+	return f.Pretty()
 }
 
 // PruneIn searches both InCache and InCreate for the given types.  When a type is found
@@ -196,23 +1167,45 @@ func (f *Func) Pretty() string {
 // Call().  There is no sense in Args() creating or providing arguments that the caller
 // will replace.
 //
+// Types are compared by identity.  An unnamed type such as an inline struct is only matched
+// by an identical unnamed type with the same fields, field types, and tags in the same order;
+// use PruneInMatch to prune such arguments with a predicate.
+//
 // Correct usage of PruneIn will provide performance increases for code using this package.
 func (f *Func) PruneIn(types ...reflect.Type) []Arg {
+	f.mutable()
+	var rv []Arg
+	for _, T := range types {
+		rv = append(rv, f.PruneInMatch(func(argT reflect.Type) bool {
+			return argT == T
+		})...)
+	}
+	return rv
+}
+
+// PruneInMatch is the same as PruneIn except arguments are removed when pred returns true for
+// their type; for example pred may match the struct kind with a field named Username.  The
+// removed arguments from InCache are returned followed by those from InCreate.
+func (f *Func) PruneInMatch(pred func(T reflect.Type) bool) []Arg {
+	f.mutable()
 	var rv []Arg
 	//
 	prune := func(slice []Arg) []Arg {
-		for _, T := range types {
-			for k, size := 0, len(slice); k < size; size = len(slice) {
-				arg := slice[k]
-				if arg.T == T {
-					rv = append(rv, arg)
-					slice = append(slice[:k], slice[k+1:]...)
-				} else {
-					k++
+		var keep []Arg
+		for k, arg := range slice {
+			if pred(arg.T) {
+				if keep == nil {
+					keep = append(make([]Arg, 0, len(slice)-1), slice[:k]...)
 				}
+				rv = append(rv, arg)
+			} else if keep != nil {
+				keep = append(keep, arg)
 			}
 		}
-		return slice
+		if keep == nil {
+			return slice
+		}
+		return keep
 	}
 	f.InCache = prune(f.InCache)
 	f.InCreate = prune(f.InCreate)
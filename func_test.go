@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/nofeaturesonlybugs/call"
 	"github.com/nofeaturesonlybugs/call/examples"
@@ -293,3 +295,1028 @@ func TestStatFunc_NonFuncPanics(t *testing.T) {
 	}()
 	call.StatFunc(chk)
 }
+
+func TestFunc_InTypeOutType(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(string, int) error { return nil })
+	T, ok := f.InType(1)
+	chk.True(ok)
+	chk.Equal(reflect.TypeOf(0), T)
+	for _, i := range []int{-1, 2} {
+		T, ok = f.InType(i)
+		chk.False(ok)
+		chk.Nil(T)
+	}
+	//
+	T, ok = f.OutType(0)
+	chk.True(ok)
+	chk.Equal(reflect.TypeOf((*error)(nil)).Elem(), T)
+	for _, i := range []int{-1, 1} {
+		T, ok = f.OutType(i)
+		chk.False(ok)
+		chk.Nil(T)
+	}
+}
+
+func ExampleFunc_CallSpread() {
+	fn := func(prefix string, values ...interface{}) {
+		fmt.Println(prefix, values)
+	}
+
+	f := call.StatFunc(fn)
+	values := []interface{}{1, "two", 3.0}
+	if _, err := f.CallSpread([]interface{}{"values:"}, values); err != nil {
+		fmt.Println(err)
+	}
+	// A nil slice spreads as zero variadic arguments.
+	if _, err := f.CallSpread([]interface{}{"none:"}, nil); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: values: [1 two 3]
+	// none: []
+}
+
+func TestFunc_CallSpread(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sum := call.StatFunc(func(scale int, nums ...int) int {
+		rv := 0
+		for _, n := range nums {
+			rv += n
+		}
+		return rv * scale
+	})
+	result, err := sum.CallSpread([]interface{}{2}, []int{1, 2, 3})
+	chk.NoError(err)
+	chk.Equal([]interface{}{12}, result.Values)
+	// Interface elements are checked individually.
+	result, err = sum.CallSpread([]interface{}{1}, []interface{}{4, 5})
+	chk.NoError(err)
+	chk.Equal([]interface{}{9}, result.Values)
+	//
+	_, err = sum.CallSpread([]interface{}{1}, []interface{}{4, "5"})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = sum.CallSpread([]interface{}{1}, []interface{}{nil})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = sum.CallSpread([]interface{}{1}, []string{"a"})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = sum.CallSpread([]interface{}{1}, 42)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = sum.CallSpread([]interface{}{"1"}, nil)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = sum.CallSpread([]interface{}{nil}, nil)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = sum.CallSpread(nil, nil)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	//
+	notVariadic := call.StatFunc(func(nums []int) {})
+	_, err = notVariadic.CallSpread(nil, []int{1})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	// CallSpread goes through Call().
+	errEmpty := fmt.Errorf("empty")
+	calls := 0
+	cache := call.NewTypeInfoCache()
+	cache.Use(func(next func(*call.Args) call.Result) func(*call.Args) call.Result {
+		return func(args *call.Args) call.Result {
+			calls++
+			return next(args)
+		}
+	})
+	m, err := cache.Stat(joiner{sep: "-"}).Methods.Named("Join")
+	chk.NoError(err)
+	result, err = m.CallSpread([]interface{}{">"}, []string{"a", "b"})
+	chk.NoError(err)
+	chk.Equal([]interface{}{">a-b"}, result.Values)
+	chk.Equal(1, calls)
+	failing := call.StatFunc(func(parts ...string) error {
+		time.Sleep(time.Millisecond)
+		return errEmpty
+	})
+	failing.MapError(errEmpty, 400)
+	failing.TimeCalls(true)
+	result, err = failing.CallSpread(nil, nil)
+	chk.NoError(err)
+	chk.Equal(400, result.Code)
+	chk.GreaterOrEqual(result.Duration, time.Millisecond)
+}
+
+func ExampleFunc_ArgsIf() {
+	fn := func(req examples.Request, sess examples.Session) {
+		fmt.Println(req.Origin, sess.Get("user"))
+	}
+
+	f := call.StatFunc(fn)
+	// Only create the Request; the Session is supplied below.
+	args := f.ArgsIf(func(arg call.Arg) bool {
+		return arg.T.Kind() != reflect.Interface
+	})
+	fmt.Println(args.Values[1].IsValid())
+	args.Pointers[0].(*examples.Request).Origin = "example.com"
+	args.Values[1] = reflect.ValueOf(examples.MapSession{"user": "bob"})
+	f.Call(args)
+
+	// Output: false
+	// example.com bob
+}
+
+func TestFunc_ArgsIf(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(a int, r examples.Response, b string) {})
+	args := f.ArgsIf(func(arg call.Arg) bool { return arg.N != 2 })
+	chk.True(args.Values[0].IsValid())
+	chk.NotNil(args.Pointers[0])
+	chk.True(args.Values[1].IsValid())
+	chk.Nil(args.Pointers[1])
+	chk.False(args.Values[2].IsValid())
+	chk.Nil(args.Pointers[2])
+	args.Values[2] = reflect.ValueOf("b")
+	f.Call(args)
+	//
+	var many examples.ManyArgs
+	m, _ := call.Stat(many).Methods.Named("Many")
+	called := 0
+	args = m.ArgsIf(func(arg call.Arg) bool {
+		called++
+		return false
+	})
+	chk.Equal(m.NumIn-1, called)
+	chk.True(args.Values[0].IsValid())
+	for k := 1; k < m.NumIn; k++ {
+		chk.False(args.Values[k].IsValid())
+	}
+}
+
+func TestFunc_SharesCachedArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(examples.Request, examples.Session) {})
+	chk.True(f.SharesCachedArgs())
+	f.PruneIn(reflect.TypeOf((*examples.Session)(nil)).Elem())
+	chk.False(f.SharesCachedArgs())
+	//
+	f = call.StatFunc(func(examples.Request) {})
+	chk.False(f.SharesCachedArgs())
+}
+
+func TestFunc_AllArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var many examples.ManyArgs
+	m, _ := call.Stat(many).Methods.Named("Many")
+	all := m.AllArgs()
+	chk.Len(all, m.NumIn-1)
+	for k, arg := range all {
+		chk.Equal(k+1, arg.N)
+		chk.Equal(m.InTypes[arg.N], arg.T)
+	}
+	// Interfaces carry their cached value.
+	chk.True(all[0].V.IsValid())
+	chk.False(all[1].V.IsValid())
+	//
+	f := call.StatFunc(func(a examples.Session, b int, c examples.Response) {})
+	f.PruneIn(reflect.TypeOf(0))
+	all = f.AllArgs()
+	if chk.Len(all, 2) {
+		chk.Equal(0, all[0].N)
+		chk.Equal(2, all[1].N)
+	}
+}
+
+func ExampleFunc_SetDefault() {
+	// Older callers do not know about the retries argument.
+	fn := func(name string, retries int) {
+		fmt.Printf("name=%v retries=%v\n", name, retries)
+	}
+
+	f := call.StatFunc(fn)
+	if err := f.SetDefault(1, 3); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.CallValues("old"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if _, err := f.CallValues("new", 5); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Output: name=old retries=3
+	// name=new retries=5
+}
+
+func TestFunc_SetDefault(t *testing.T) {
+	chk := assert.New(t)
+	//
+	fn := func(s string, sess examples.Session, n int) (string, examples.Session, int) {
+		return s, sess, n
+	}
+	f := call.StatFunc(fn)
+	chk.ErrorIs(f.SetDefault(-1, "a"), call.ErrNotFound)
+	chk.ErrorIs(f.SetDefault(3, "a"), call.ErrNotFound)
+	chk.ErrorIs(f.SetDefault(0, 42), call.ErrIncompatible)
+	chk.ErrorIs(f.SetDefault(0, nil), call.ErrIncompatible)
+	//
+	cp := *f
+	chk.NoError(f.SetDefault(0, "a"))
+	chk.NoError(f.SetDefault(2, 42))
+	session := examples.MapSession{}
+	chk.NoError(f.SetDefault(1, session))
+	// Each *Args receives its own copy of the default.
+	args := f.Args()
+	chk.Equal("a", *args.Pointers[0].(*string))
+	*args.Pointers[0].(*string) = "b"
+	chk.Equal([]interface{}{"b", session, 42}, f.Call(args).Values)
+	args = f.Args()
+	chk.Equal([]interface{}{"a", session, 42}, f.Call(args).Values)
+	// ArgsIf also honors defaults.
+	args = f.ArgsIf(func(call.Arg) bool { return true })
+	chk.Equal([]interface{}{"a", session, 42}, f.Call(args).Values)
+	// Copies of the Func are not affected.
+	args = cp.Args()
+	chk.Equal([]interface{}{"", nil, 0}, cp.Call(args).Values)
+	// Pruned arguments do not accept defaults.
+	f.PruneIn(reflect.TypeOf(0))
+	chk.ErrorIs(f.SetDefault(2, 1), call.ErrNotFound)
+}
+
+func TestFunc_CallValues(t *testing.T) {
+	chk := assert.New(t)
+	//
+	fn := func(s string, sess examples.Session, n int) (string, examples.Session, int) {
+		return s, sess, n
+	}
+	f := call.StatFunc(fn)
+	result, err := f.CallValues()
+	chk.NoError(err)
+	chk.Equal([]interface{}{"", nil, 0}, result.Values)
+	result, err = f.CallValues("a", nil, 1)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a", nil, 1}, result.Values)
+	//
+	_, err = f.CallValues("a", nil, 1, 2)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = f.CallValues(1)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	// Pruned arguments that are not given receive zero values.
+	f.PruneIn(reflect.TypeOf(0))
+	result, err = f.CallValues("b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"b", nil, 0}, result.Values)
+}
+
+func TestFunc_CallValues_PointerAdaptation(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Request struct {
+		ID int
+	}
+	byValue := call.StatFunc(func(req Request) int { return req.ID })
+	result, err := byValue.CallValues(&Request{ID: 1})
+	chk.NoError(err)
+	chk.Equal([]interface{}{1}, result.Values)
+	_, err = byValue.CallValues((*Request)(nil))
+	chk.ErrorIs(err, call.ErrIncompatible)
+	//
+	byPointer := call.StatFunc(func(req *Request) int { return req.ID })
+	_, err = byPointer.CallValues(Request{ID: 2})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	// Addressable values can be supplied through SetValue.
+	holder := &struct{ Req Request }{Req: Request{ID: 3}}
+	args := byPointer.Args()
+	chk.NoError(byPointer.SetValue(args, 0, reflect.ValueOf(holder).Elem().Field(0)))
+	chk.Nil(args.Pointers[0])
+	chk.Equal([]interface{}{3}, byPointer.Call(args).Values)
+	//
+	args = byPointer.Args()
+	chk.ErrorIs(byPointer.SetValue(args, 0, reflect.ValueOf(Request{})), call.ErrIncompatible)
+	chk.ErrorIs(byPointer.Set(args, 1, &Request{}), call.ErrNotFound)
+	chk.NoError(byPointer.Set(args, 0, &Request{ID: 4}))
+	chk.Equal([]interface{}{4}, byPointer.Call(args).Values)
+}
+
+func ExampleFunc_SetArgPool() {
+	type Request struct {
+		Path string
+	}
+	// free is a simple application pool; a sync.Pool is typical.
+	var free []*Request
+	get := func() reflect.Value {
+		if len(free) == 0 {
+			fmt.Println("new request")
+			return reflect.ValueOf(&Request{})
+		}
+		req := free[len(free)-1]
+		free = free[:len(free)-1]
+		return reflect.ValueOf(req)
+	}
+	put := func(V reflect.Value) {
+		req := V.Interface().(*Request)
+		*req = Request{}
+		free = append(free, req)
+	}
+	handler := func(req *Request) {
+		fmt.Println("handling", req.Path)
+	}
+
+	f := call.StatFunc(handler)
+	f.SetArgPool(reflect.TypeOf(&Request{}), get, put)
+	for _, path := range []string{"/a", "/b"} {
+		args := f.Args()
+		(*args.Pointers[0].(**Request)).Path = path
+		f.Call(args)
+	}
+
+	// Output: new request
+	// handling /a
+	// handling /b
+}
+
+func TestFunc_SetArgPool(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var gets, puts int
+	var put []*int
+	f := call.StatFunc(func(a *int, s string, b *int) {})
+	cp := *f
+	f.SetArgPool(reflect.TypeOf((*int)(nil)),
+		func() reflect.Value {
+			gets++
+			return reflect.ValueOf(new(int))
+		},
+		func(V reflect.Value) {
+			puts++
+			put = append(put, V.Interface().(*int))
+		},
+	)
+	args := f.Args()
+	chk.Equal(2, gets)
+	a := *args.Pointers[0].(**int)
+	chk.NotNil(a)
+	// Clones do not return values to the pool.
+	f.Call(args.Clone())
+	chk.Equal(0, puts)
+	f.Call(args)
+	chk.Equal(2, puts)
+	chk.True(put[0] == a)
+	// ArgsIf draws from the pool as well.
+	args = f.ArgsIf(func(arg call.Arg) bool { return arg.N == 0 })
+	chk.Equal(3, gets)
+	args.Values[1], args.Values[2] = reflect.ValueOf(""), reflect.ValueOf((*int)(nil))
+	f.Call(args)
+	chk.Equal(3, puts)
+	// Copies of the Func are unaffected.
+	args = cp.Args()
+	chk.Nil(*args.Pointers[0].(**int))
+	cp.Call(args)
+	chk.Equal(3, gets)
+	// A nil put is allowed.
+	f.SetArgPool(reflect.TypeOf((*int)(nil)), func() reflect.Value { return reflect.ValueOf(new(int)) }, nil)
+	f.Call(f.Args())
+	chk.Equal(3, puts)
+}
+
+func TestFunc_SetZeroPooled(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Inner struct{ Tags []string }
+	type Request struct {
+		Path  string
+		Inner Inner
+	}
+	dirty := &Request{Path: "/stale", Inner: Inner{Tags: []string{"stale"}}}
+	f := call.StatFunc(func(req *Request, n *int, s Request) (Request, Request) { return *req, s })
+	get := func() reflect.Value { return reflect.ValueOf(dirty) }
+	f.SetArgPool(reflect.TypeOf(dirty), get, nil)
+	f.SetArgPool(reflect.TypeOf((*int)(nil)), func() reflect.Value { n := 7; return reflect.ValueOf(&n) }, nil)
+	f.SetArgPool(reflect.TypeOf(Request{}), func() reflect.Value { return reflect.ValueOf(*dirty) }, nil)
+	//
+	args := f.Args()
+	chk.Equal("/stale", (*args.Pointers[0].(**Request)).Path)
+	args.Release()
+	//
+	f.SetZeroPooled(true)
+	args = f.Args()
+	// The recycled pointer is kept but the struct it points to is zeroed.
+	chk.True(dirty == *args.Pointers[0].(**Request))
+	chk.Equal(7, **args.Pointers[1].(**int))
+	result := f.Call(args)
+	chk.Equal([]interface{}{Request{}, Request{}}, result.Values)
+	chk.Equal(&Request{}, dirty)
+}
+
+func TestFunc_ArgsValues_Resolution(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Request struct{ Path string }
+	dirty := &Request{Path: "/stale"}
+	f := call.StatFunc(func(n int, sess examples.Session, req *Request, s Request) (int, interface{}, Request, Request) {
+		return n, sess.Get("k"), *req, s
+	})
+	chk.NoError(f.SetArgName(0, "n"))
+	chk.NoError(f.SetNamedProvider("n", func() reflect.Value { return reflect.ValueOf(42) }))
+	chk.NoError(f.BindInterface(reflect.TypeOf((*examples.Session)(nil)).Elem(), func() reflect.Value {
+		return reflect.ValueOf(examples.MapSession{"k": "v"})
+	}))
+	f.SetArgPool(reflect.TypeOf(dirty), func() reflect.Value { return reflect.ValueOf(dirty) }, nil)
+	f.SetArgPool(reflect.TypeOf(Request{}), func() reflect.Value { return reflect.ValueOf(*dirty) }, nil)
+	//
+	chk.Equal([]interface{}{42, "v", *dirty, *dirty}, f.Call(f.ArgsValues()).Values)
+	// Pooled values are zeroed as they are by Args().
+	f.SetZeroPooled(true)
+	chk.Equal([]interface{}{42, "v", Request{}, Request{}}, f.Call(f.ArgsValues()).Values)
+	chk.Equal(&Request{}, dirty)
+}
+
+func ExampleFunc_RisksNilPanic() {
+	handler := func(counts map[string]int, name string, sess examples.Session) {
+		counts[name]++
+	}
+
+	f := call.StatFunc(handler)
+	for _, arg := range f.RisksNilPanic() {
+		fmt.Printf("argument %v (%v) must be supplied\n", arg.N, arg.T)
+	}
+
+	// Output: argument 0 (map[string]int) must be supplied
+	// argument 2 (examples.Session) must be supplied
+}
+
+func TestFunc_RisksNilPanic(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func([]int, chan int, *int, func(), int, struct{}, map[int]int) {})
+	var indexes []int
+	for _, arg := range f.RisksNilPanic() {
+		indexes = append(indexes, arg.N)
+	}
+	chk.Equal([]int{0, 1, 2, 3, 6}, indexes)
+	// Defaults and pools remove the risk.
+	chk.NoError(f.SetDefault(6, map[int]int{}))
+	f.SetArgPool(reflect.TypeOf((*int)(nil)), func() reflect.Value { return reflect.ValueOf(new(int)) }, nil)
+	f.PruneIn(reflect.TypeOf([]int{}))
+	indexes = nil
+	for _, arg := range f.RisksNilPanic() {
+		indexes = append(indexes, arg.N)
+	}
+	chk.Equal([]int{1, 3}, indexes)
+	// A nil default is still a risk.
+	chk.NoError(f.SetDefault(1, nil))
+	chk.Len(f.RisksNilPanic(), 2)
+}
+
+func ExampleNewArgs() {
+	fn := func(str string, num int) {
+		fmt.Printf("str=%v num=%v\n", str, num)
+	}
+
+	// The values might come from a recorded call.
+	values := []reflect.Value{reflect.ValueOf("Hi!"), reflect.ValueOf(42)}
+
+	f := call.StatFunc(fn)
+	f.Call(call.NewArgs(values...))
+
+	// Output: str=Hi! num=42
+}
+
+func ExampleFunc_CallStruct() {
+	type Report struct {
+		Rows  [64]int
+		Total int
+	}
+	build := func(n int) (Report, error) {
+		var report Report
+		for k := 0; k < n; k++ {
+			report.Rows[k] = k
+			report.Total += k
+		}
+		return report, nil
+	}
+
+	f := call.StatFunc(build)
+	args := f.Args()
+	*args.Pointers[0].(*int) = 5
+	var report Report
+	if err := f.CallStruct(args, &report); err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(report.Total)
+
+	// Output: 10
+}
+
+func TestFunc_CallStruct(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Out struct{ N int }
+	var zeroValue reflect.Value
+	errOops := fmt.Errorf("oops")
+	f := call.StatFunc(func(n int) (int, Out, error) {
+		if n < 0 {
+			return 0, Out{}, errOops
+		}
+		return 1, Out{N: n}, nil
+	})
+	var out Out
+	args := f.Args()
+	*args.Pointers[0].(*int) = 3
+	chk.NoError(f.CallStruct(args, &out))
+	chk.Equal(3, out.N)
+	args = f.Args()
+	*args.Pointers[0].(*int) = -1
+	chk.ErrorIs(f.CallStruct(args, &out), errOops)
+	// dst may be an interface.
+	var iface interface{}
+	chk.NoError(f.CallStruct(f.Args(), &iface))
+	chk.Equal(Out{}, iface)
+	//
+	chk.ErrorIs(f.CallStruct(f.Args(), out), call.ErrIncompatible)
+	chk.ErrorIs(f.CallStruct(f.Args(), (*Out)(nil)), call.ErrIncompatible)
+	chk.ErrorIs(f.CallStruct(f.Args(), new(int)), call.ErrIncompatible)
+	g := call.StatFunc(func() int { return 0 })
+	chk.ErrorIs(g.CallStruct(g.Args(), &out), call.ErrNotFound)
+	// Errors that prevent the call leave dst unchanged.
+	f.PruneIn(reflect.TypeOf(0))
+	f.OnMissingArg = func(call.Arg) (reflect.Value, bool) { return zeroValue, false }
+	out = Out{N: 9}
+	chk.ErrorIs(f.CallStruct(f.Args(), &out), call.ErrNotFound)
+	chk.Equal(9, out.N)
+	// Error types that are not nilable are always returned.
+	h := call.StatFunc(func() (Out, statusError) { return Out{N: 1}, statusError(404) })
+	chk.Equal(statusError(404), h.CallStruct(h.Args(), &out))
+	chk.Equal(1, out.N)
+}
+
+// statusError is an error type whose zero value is not nil.
+type statusError int
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("status %d", int(e))
+}
+
+func TestArgs_ConcreteType(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(sess examples.Session, n int, p *int) {})
+	args := f.Args()
+	_, ok := args.ConcreteType(0)
+	chk.False(ok)
+	chk.NoError(f.Set(args, 0, examples.MapSession{}))
+	T, ok := args.ConcreteType(0)
+	chk.True(ok)
+	chk.Equal(reflect.TypeOf(examples.MapSession{}), T)
+	T, ok = args.ConcreteType(1)
+	chk.True(ok)
+	chk.Equal(reflect.TypeOf(0), T)
+	_, ok = args.ConcreteType(2)
+	chk.False(ok)
+	_, ok = args.ConcreteType(3)
+	chk.False(ok)
+	f.Call(args)
+}
+
+func TestFunc_SetNil(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Request struct{}
+	f := call.StatFunc(func(req *Request, n int, sess examples.Session) bool { return req == nil && sess == nil })
+	args := f.Args()
+	chk.NoError(f.Set(args, 0, &Request{}))
+	chk.NoError(f.SetNil(args, 0))
+	chk.ErrorIs(f.SetNil(args, 1), call.ErrIncompatible)
+	chk.ErrorIs(f.SetNil(args, 3), call.ErrNotFound)
+	// The parameter type is used rather than the type of the current value.
+	args.Values[2] = reflect.ValueOf(examples.MapSession{})
+	chk.NoError(f.SetNil(args, 2))
+	chk.Equal(reflect.TypeOf((*examples.Session)(nil)).Elem(), args.Values[2].Type())
+	chk.Equal([]interface{}{true}, f.Call(args).Values)
+}
+
+func ExampleStatFuncMap() {
+	plugins := map[string]interface{}{
+		"greet": examples.Person.Greet,
+		"upper": strings.ToUpper,
+	}
+	funcs, err := call.StatFuncMap(plugins)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	result, _ := funcs["greet"].CallValues(examples.Person{Name: "Bob", Age: 40}) // error ignored for brevity
+	fmt.Println(result.Values[0])
+	result, _ = funcs["upper"].CallValues("hello") // error ignored for brevity
+	fmt.Println(result.Values[0])
+
+	// Output: Hello!  My name is Bob and I am 40 year(s) old.
+	// HELLO
+}
+
+func TestStatFuncMap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	funcs, err := call.StatFuncMap(nil)
+	chk.NoError(err)
+	chk.Empty(funcs)
+	_, err = call.StatFuncMap(map[string]interface{}{"ok": strings.ToUpper, "bad": 42})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	chk.Contains(err.Error(), "bad")
+	_, err = call.StatFuncMap(map[string]interface{}{"nil": nil})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = call.StatFuncMap(map[string]interface{}{"typed": (func())(nil)})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	chk.Contains(err.Error(), "typed")
+}
+
+func ExampleFunc_OnMissingArg() {
+	fn := func(req examples.Request, store examples.Session) {
+		store.Set("message", "Hello, World!")
+	}
+	sess := examples.MapSession{}
+
+	f := call.StatFunc(fn)
+	f.PruneIn(reflect.TypeOf((*examples.Session)(nil)).Elem())
+	// The session is resolved when the call is made instead of when Args() is called.
+	f.OnMissingArg = func(arg call.Arg) (reflect.Value, bool) {
+		return reflect.ValueOf(sess), arg.T == reflect.TypeOf((*examples.Session)(nil)).Elem()
+	}
+	f.Call(f.Args())
+	fmt.Println(sess.Get("message"))
+
+	// Output: Hello, World!
+}
+
+func TestFunc_OnMissingArg(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(s string, n int) string { return fmt.Sprint(s, n) })
+	f.PruneIn(reflect.TypeOf(""), reflect.TypeOf(0))
+	var missing []call.Arg
+	f.OnMissingArg = func(arg call.Arg) (reflect.Value, bool) {
+		missing = append(missing, arg)
+		return reflect.ValueOf("x"), arg.N == 0
+	}
+	result := f.Call(f.Args())
+	chk.Nil(result.Values)
+	chk.ErrorIs(result.Error, call.ErrNotFound)
+	var argErr *call.ArgError
+	if chk.ErrorAs(result.Error, &argErr) {
+		chk.Equal(1, argErr.N)
+	}
+	chk.Equal([]call.Arg{{N: 0, T: reflect.TypeOf("")}, {N: 1, T: reflect.TypeOf(0)}}, missing)
+	// Incompatible values are errors.
+	f.OnMissingArg = func(arg call.Arg) (reflect.Value, bool) {
+		return reflect.ValueOf("x"), true
+	}
+	chk.ErrorIs(f.Call(f.Args()).Error, call.ErrIncompatible)
+	// Provided values are not replaced; CallInto honors the hook.
+	args := f.Args()
+	args.Values[1] = reflect.ValueOf(1)
+	var into call.Result
+	f.CallInto(args, &into)
+	chk.Equal([]interface{}{"x1"}, into.Values)
+}
+
+func TestFunc_UnfilledInterfaces(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sessionType := reflect.TypeOf((*examples.Session)(nil)).Elem()
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	f := call.StatFunc(func(a examples.Session, n int, b examples.Session, err error) {})
+	args := f.Args()
+	chk.Equal([]call.Arg{{N: 0, T: sessionType}, {N: 2, T: sessionType}, {N: 3, T: errorType}}, f.UnfilledInterfaces(args))
+	chk.NoError(f.Set(args, 0, examples.MapSession{}))
+	args.Values[3] = reflect.ValueOf(fmt.Errorf("err"))
+	chk.Equal([]call.Arg{{N: 2, T: sessionType}}, f.UnfilledInterfaces(args))
+	f.Call(args)
+	// Pruned arguments that were not supplied are unfilled.
+	f.PruneIn(sessionType)
+	args = f.Args()
+	chk.Len(f.UnfilledInterfaces(args), 3)
+}
+
+func TestFunc_OnCreateArg(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Options struct {
+		Limit int
+	}
+	f := call.StatFunc(func(s string, opts Options, sess examples.Session) int { return opts.Limit })
+	var created []int
+	f.OnCreateArg = func(arg call.Arg, v reflect.Value) {
+		created = append(created, arg.N)
+		if opts, ok := v.Addr().Interface().(*Options); ok {
+			opts.Limit = 10
+		}
+	}
+	chk.Equal([]interface{}{10}, f.Call(f.Args()).Values)
+	chk.Equal([]int{0, 1}, created)
+	// Copies of an Instance keep the hook.
+	m, err := call.Stat(joiner{}).Methods.Named("Pair")
+	chk.NoError(err)
+	m.OnCreateArg = func(arg call.Arg, v reflect.Value) {
+		v.SetString("x")
+	}
+	cp, err := m.Instance().Copy().Methods.Named("Pair")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"xx"}, cp.Call(cp.ArgsIf(func(call.Arg) bool { return true })).Values)
+}
+
+func TestFunc_PruneInMatch(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(examples.HTTP{})
+	m, err := instance.Methods.Named("Handler")
+	chk.NoError(err)
+	cp := instance.Copy()
+	mcp, err := cp.Methods.Named("Handler")
+	chk.NoError(err)
+	// The inline struct argument is matched by its shape.
+	pruned := mcp.PruneInMatch(func(T reflect.Type) bool {
+		if T.Kind() != reflect.Struct {
+			return false
+		}
+		_, ok := T.FieldByName("Username")
+		return ok
+	})
+	if chk.Len(pruned, 1) {
+		chk.Equal(4, pruned[0].N)
+	}
+	chk.Len(mcp.AllArgs(), 3)
+	// The original is not affected.
+	if args := m.AllArgs(); chk.Len(args, 4) {
+		chk.Equal(4, args[3].N)
+	}
+	m.Call(m.Args())
+	chk.Empty(mcp.PruneInMatch(func(reflect.Type) bool { return false }))
+}
+
+// config is a large read-only argument.
+type config struct {
+	Limits [64]int
+	Name   string
+}
+
+func TestFunc_MarkImmutable(t *testing.T) {
+	chk := assert.New(t)
+	//
+	cfg := config{Name: "prod"}
+	f := call.StatFunc(func(cfg config, s string) string { return cfg.Name + s })
+	chk.NoError(f.MarkImmutable(0, reflect.ValueOf(cfg)))
+	chk.Len(f.InCreate, 1)
+	args := f.Args()
+	chk.Nil(args.Pointers[0])
+	chk.NotNil(args.Pointers[1])
+	chk.Equal([]interface{}{"prod"}, f.Call(args).Values)
+	// The argument is no longer created.
+	chk.ErrorIs(f.MarkImmutable(0, reflect.ValueOf(cfg)), call.ErrNotFound)
+	chk.ErrorIs(f.MarkImmutable(1, reflect.ValueOf(1)), call.ErrIncompatible)
+	chk.ErrorIs(f.MarkImmutable(1, reflect.Value{}), call.ErrIncompatible)
+	chk.ErrorIs(f.MarkImmutable(2, reflect.ValueOf("")), call.ErrNotFound)
+	chk.True(f.SharesCachedArgs())
+}
+
+func BenchmarkFunc_MarkImmutable(b *testing.B) {
+	fn := func(cfg config, n int) int { return cfg.Limits[n] }
+	for _, immutable := range []bool{false, true} {
+		b.Run(fmt.Sprintf("immutable=%v", immutable), func(b *testing.B) {
+			f := call.StatFunc(fn)
+			if immutable {
+				if err := f.MarkImmutable(0, reflect.ValueOf(config{})); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for k := 0; k < b.N; k++ {
+				f.Call(f.Args())
+			}
+		})
+	}
+}
+
+func TestFunc_CallValuesSkipInterfaces(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sess := examples.MapSession{}
+	f := call.StatFunc(func(s string, sess examples.Session, n int) (string, examples.Session, int) {
+		return s, sess, n
+	})
+	result, err := f.CallValuesSkipInterfaces("a", 1)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a", nil, 1}, result.Values)
+	chk.NoError(f.SetDefault(1, examples.Session(sess)))
+	result, err = f.CallValuesSkipInterfaces("b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"b", sess, 0}, result.Values)
+	//
+	_, err = f.CallValuesSkipInterfaces("a", 1, 2)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = f.CallValuesSkipInterfaces(1)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	// Pruned interface arguments are passed as the nil interface.
+	f.PruneIn(reflect.TypeOf((*examples.Session)(nil)).Elem())
+	result, err = f.CallValuesSkipInterfaces("c", 3)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"c", nil, 3}, result.Values)
+	//
+	m, err := call.Stat(calculator{}).Methods.Named("Scale")
+	chk.NoError(err)
+	result, err = m.CallValuesSkipInterfaces(2, struct{ X, Y int }{X: 1, Y: 2})
+	chk.NoError(err)
+	chk.Equal([]interface{}{2, 4}, result.Values)
+}
+
+func TestFunc_TimeCalls(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(d time.Duration) string {
+		time.Sleep(d)
+		return "done"
+	})
+	args := f.Args()
+	*args.Pointers[0].(*time.Duration) = 10 * time.Millisecond
+	result := f.Call(args)
+	chk.Equal(time.Duration(0), result.Duration)
+	//
+	f.TimeCalls(true)
+	args = f.Args()
+	*args.Pointers[0].(*time.Duration) = 10 * time.Millisecond
+	result = f.Call(args)
+	chk.Equal([]interface{}{"done"}, result.Values)
+	chk.GreaterOrEqual(result.Duration, 10*time.Millisecond)
+	//
+	args = f.Args()
+	f.CallInto(args, &result)
+	chk.Greater(result.Duration, time.Duration(0))
+	chk.Less(result.Duration, 10*time.Millisecond)
+	result.Reset()
+	chk.Equal(time.Duration(0), result.Duration)
+}
+
+func TestFunc_SetNamedProvider(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type replica struct{ Name string }
+	read, write := &replica{Name: "read"}, &replica{Name: "write"}
+	sess := examples.MapSession{}
+	f := call.StatFunc(func(r, w *replica, sess examples.Session) (string, string, examples.Session) {
+		return r.Name, w.Name, sess
+	})
+	chk.NoError(f.SetArgName(0, "read"))
+	chk.NoError(f.SetArgName(1, "write"))
+	chk.NoError(f.SetArgName(2, "session"))
+	chk.ErrorIs(f.SetArgName(3, "other"), call.ErrNotFound)
+	// The named provider takes precedence over the default.
+	chk.NoError(f.SetDefault(0, &replica{Name: "default"}))
+	chk.NoError(f.SetNamedProvider("read", func() reflect.Value { return reflect.ValueOf(read) }))
+	chk.NoError(f.SetNamedProvider("write", func() reflect.Value { return reflect.ValueOf(write) }))
+	chk.NoError(f.SetNamedProvider("session", func() reflect.Value { return reflect.ValueOf(sess) }))
+	// Registering a name twice is an error.
+	err := f.SetNamedProvider("read", func() reflect.Value { return reflect.ValueOf(write) })
+	chk.ErrorIs(err, call.ErrAmbiguous)
+	//
+	args := f.Args()
+	chk.Equal(&read, args.Pointers[0])
+	result := f.Call(args)
+	chk.Equal([]interface{}{"read", "write", sess}, result.Values)
+	//
+	result = f.Call(f.ArgsIf(func(call.Arg) bool { return true }))
+	chk.Equal([]interface{}{"read", "write", sess}, result.Values)
+	// Removing a provider restores the default.
+	chk.NoError(f.SetNamedProvider("read", nil))
+	result = f.Call(f.Args())
+	chk.Equal([]interface{}{"default", "write", sess}, result.Values)
+}
+
+// Option is a variadic argument of command handlers.
+type Option struct{ Name string }
+
+func ExampleFunc_variadic() {
+	handler := func(name string, opts ...Option) string {
+		for _, opt := range opts {
+			name += " " + opt.Name
+		}
+		return name
+	}
+
+	f := call.StatFunc(handler)
+	args := f.Args()
+	*args.Pointers[0].(*string) = "run"
+	opts := args.Pointers[1].(*[]Option)
+	*opts = append(*opts, Option{Name: "-v"}, Option{Name: "-q"})
+	fmt.Println(f.Call(args).Values...)
+
+	// Output: run -v -q
+}
+
+func TestFunc_Variadic(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var got []Option
+	f := call.StatFunc(func(name string, n int, opts ...Option) int {
+		got = opts
+		return len(opts)
+	})
+	chk.True(f.IsVariadic)
+	chk.Equal(reflect.TypeOf(Option{}), f.VariadicElem)
+	chk.False(call.StatFunc(func(opts []Option) {}).IsVariadic)
+	chk.Nil(call.StatFunc(func(opts []Option) {}).VariadicElem)
+	// Zero elements.
+	result := f.Call(f.Args())
+	chk.Equal([]interface{}{0}, result.Values)
+	chk.Nil(got)
+	// One element.
+	args := f.Args()
+	args.Values[2] = reflect.ValueOf([]Option{{Name: "a"}})
+	chk.Equal([]interface{}{1}, f.Call(args).Values)
+	chk.Equal([]Option{{Name: "a"}}, got)
+	// Several elements mixed with fixed arguments.
+	result, err := f.CallValues("cmd", 2, []Option{{Name: "a"}, {Name: "b"}, {Name: "c"}})
+	chk.NoError(err)
+	chk.Equal([]interface{}{3}, result.Values)
+	chk.Len(got, 3)
+	//
+	args = f.Args()
+	*args.Pointers[2].(*[]Option) = []Option{{Name: "x"}, {Name: "y"}}
+	var into call.Result
+	f.CallInto(args, &into)
+	chk.Equal([]interface{}{2}, into.Values)
+	f.TimeCalls(true)
+	chk.Equal([]interface{}{0}, f.Call(f.Args()).Values)
+	// Methods are variadic as well.
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Join")
+	chk.NoError(err)
+	chk.True(m.IsVariadic)
+	result, err = m.CallValues(">", []string{"a", "b"})
+	chk.NoError(err)
+	chk.Equal([]interface{}{">a-b"}, result.Values)
+	result = m.Call(m.Args())
+	chk.Equal([]interface{}{""}, result.Values)
+}
+
+func TestFunc_BindInterface(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sessionType := reflect.TypeOf((*examples.Session)(nil)).Elem()
+	f := call.StatFunc(func(a examples.Session, n int, b examples.Session) bool {
+		a.Set("n", n)
+		return b.Get("n") == nil
+	})
+	created := 0
+	chk.NoError(f.BindInterface(sessionType, func() reflect.Value {
+		created++
+		return reflect.ValueOf(examples.MapSession{})
+	}))
+	chk.Empty(f.InCache)
+	chk.Len(f.InCreate, 3)
+	chk.Empty(f.RisksNilPanic())
+	// Each argument receives a new value with an addressable pointer.
+	args := f.Args()
+	chk.Equal(2, created)
+	chk.IsType(examples.MapSession{}, *args.Pointers[0].(*examples.Session))
+	chk.Equal([]interface{}{true}, f.Call(args).Values)
+	// The binding takes precedence over a default.
+	chk.NoError(f.SetDefault(2, examples.Session(nil)))
+	args = f.Args()
+	chk.NotNil(*args.Pointers[2].(*examples.Session))
+	args.Release()
+	// Binding again is an error; the binding is removed to replace it.
+	other := func() reflect.Value {
+		return reflect.ValueOf(examples.MapSession{"n": 0})
+	}
+	chk.ErrorIs(f.BindInterface(sessionType, other), call.ErrAmbiguous)
+	chk.NoError(f.BindInterface(sessionType, nil))
+	chk.NoError(f.BindInterface(sessionType, other))
+	chk.Equal([]interface{}{false}, f.Call(f.Args()).Values)
+	chk.Equal(4, created)
+	config := f.SaveConfig()
+	//
+	chk.NoError(f.BindInterface(sessionType, nil))
+	chk.Len(f.InCache, 2)
+	chk.Len(f.InCreate, 1)
+	args = f.Args()
+	chk.Nil(args.Pointers[0])
+	chk.True(args.Values[2].IsNil())
+	args.Release()
+	// Restoring a configuration restores its bindings.
+	chk.NoError(f.RestoreConfig(config))
+	chk.Len(f.InCreate, 3)
+	chk.Equal([]interface{}{false}, f.Call(f.Args()).Values)
+	chk.NoError(f.BindInterface(sessionType, nil))
+	// Only interfaces can be bound.
+	err := f.BindInterface(reflect.TypeOf(0), func() reflect.Value { return reflect.ValueOf(1) })
+	chk.ErrorIs(err, call.ErrIncompatible)
+	chk.Len(f.InCreate, 1)
+	//
+	m, err := call.Stat(examples.ManyArgs{}).Methods.Named("Many")
+	chk.NoError(err)
+	chk.NoError(m.BindInterface(sessionType, func() reflect.Value { return reflect.ValueOf(examples.MapSession{}) }))
+	args = m.Args()
+	chk.NotNil(args.Values[3].Interface())
+	args.Release()
+}
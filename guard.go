@@ -0,0 +1,55 @@
+package call
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the error returned by Guard and SafeCall when a panic is recovered.
+type PanicError struct {
+	// Value is the value returned by recover().
+	Value interface{}
+	// Stack is the stack trace captured when the panic was recovered.
+	Stack []byte
+}
+
+// Error returns the recovered value and the stack trace as a string.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the recovered value if it is an error and nil otherwise.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Guard invokes fn and recovers from any panic that occurs.  When a panic is recovered
+// the returned error is a *PanicError and the returned Result is empty except that it
+// retains the recovered value for Result.Repanic.
+//
+// Guard is intended to wrap Call:
+//
+//	args := f.Args()
+//	result, err := call.Guard(func() call.Result {
+//		return f.Call(args)
+//	})
+//
+// Call returns its arguments to the pool in a deferred function; that deferred function
+// runs before Guard recovers the panic so pooled resources are not leaked.
+func Guard(fn func() Result) (rv Result, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			rv, err = Result{panicked: true, recovered: r}, &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return fn(), nil
+}
+
+// SafeCall is the same as Call except any panic is recovered and returned as a *PanicError;
+// see Guard.
+func (f *Func) SafeCall(args *Args) (Result, error) {
+	return Guard(func() Result {
+		return f.Call(args)
+	})
+}
@@ -0,0 +1,88 @@
+package call_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+func ExampleGuard() {
+	fn := func(m map[string]int) {
+		m["answer"] = 42 // Args() creates a nil map so this panics.
+	}
+
+	f := call.StatFunc(fn)
+	args := f.Args()
+	_, err := call.Guard(func() call.Result {
+		return f.Call(args)
+	})
+	var perr *call.PanicError
+	if errors.As(err, &perr) {
+		fmt.Println(perr.Value)
+	}
+
+	// Output: assignment to entry in nil map
+}
+
+func TestFunc_SafeCall(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sentinel := fmt.Errorf("sentinel")
+	f := call.StatFunc(func(n int) int {
+		if n == 0 {
+			panic(sentinel)
+		}
+		return n
+	})
+	//
+	args := f.Args()
+	result, err := f.SafeCall(args)
+	chk.Error(err)
+	chk.ErrorIs(err, sentinel)
+	chk.Nil(result.Values)
+	// The pool cleanup in Call still ran.
+	chk.Equal(reflect.Value{}, args.Values[0])
+	chk.Nil(args.Pointers[0])
+	var perr *call.PanicError
+	chk.True(errors.As(err, &perr))
+	chk.NotEmpty(perr.Stack)
+	chk.Contains(err.Error(), "sentinel")
+	//
+	args = f.Args()
+	*args.Pointers[0].(*int) = 5
+	result, err = f.SafeCall(args)
+	chk.NoError(err)
+	chk.Equal([]interface{}{5}, result.Values)
+	//
+	// Non-error panic values do not unwrap.
+	_, err = call.Guard(func() call.Result { panic("string") })
+	chk.Nil(errors.Unwrap(err))
+}
+
+func TestResult_Repanic(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sentinel := fmt.Errorf("sentinel")
+	f := call.StatFunc(func(n int) int {
+		if n == 0 {
+			panic(sentinel)
+		}
+		return n
+	})
+	result, err := f.SafeCall(f.Args())
+	chk.Error(err)
+	chk.True(result.Panicked())
+	chk.PanicsWithValue(sentinel, result.Repanic)
+	//
+	args := f.Args()
+	*args.Pointers[0].(*int) = 1
+	result, err = f.SafeCall(args)
+	chk.NoError(err)
+	chk.False(result.Panicked())
+	chk.NotPanics(result.Repanic)
+}
@@ -0,0 +1,141 @@
+package call
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+var (
+	// requestType and responseWriterType are the reflect.Type of *http.Request and
+	// http.ResponseWriter.
+	requestType        = reflect.TypeOf((*http.Request)(nil))
+	responseWriterType = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+)
+
+// HTTPHandler returns an http.Handler that invokes the function for each request; it is a
+// ready-made version of the handler factory in the package examples.
+//
+// Arguments of type http.ResponseWriter and *http.Request receive the values passed to
+// ServeHTTP.  If the media type of the request's Content-Type is application/json, with or
+// without parameters such as charset, the request body is decoded into each struct argument;
+// a body that is not valid JSON is answered with http.StatusBadRequest.
+//
+// If the function returns an error the handler responds with http.StatusInternalServerError
+// and the error text.  Otherwise each non-error return value is written to the response with
+// EncodeAuto; functions that write their own response should not return other values.
+func (f *Func) HTTPHandler() http.Handler {
+	return f.httpHandler(f.Args)
+}
+
+// isJSON returns true if the media type of the request's Content-Type is application/json.
+func isJSON(req *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
+
+// httpHandler is the implementation of HTTPHandler; newArgs creates the arguments for each
+// request.
+func (f *Func) httpHandler(newArgs func() *Args) http.Handler {
+	fn := func(w http.ResponseWriter, req *http.Request) {
+		args := newArgs()
+		for k, T := range f.InTypes {
+			switch T {
+			case requestType:
+				args.Values[k], args.Pointers[k] = reflect.ValueOf(req), nil
+			case responseWriterType:
+				args.Values[k], args.Pointers[k] = reflect.ValueOf(&w).Elem(), nil
+			}
+		}
+		if isJSON(req) && req.Body != nil {
+			err := decodeArgs(args, func() error {
+				var body json.RawMessage
+				err := json.NewDecoder(req.Body).Decode(&body)
+				if err == io.EOF {
+					// An empty body leaves the struct arguments as created.
+					return nil
+				} else if err != nil {
+					return err
+				}
+				return f.structArgs(args, func(n int, pointer interface{}) error {
+					return json.Unmarshal(body, pointer)
+				})
+			})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		result := f.Call(args)
+		if result.Error != nil {
+			http.Error(w, result.Error.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, v := range result.Payload() {
+			if v == nil {
+				continue
+			}
+			if err := EncodeAuto(w, v); err != nil {
+				return
+			}
+		}
+	}
+	return http.HandlerFunc(fn)
+}
+
+// Router dispatches HTTP requests to methods by request method and path.  The zero value
+// is ready to use.
+//
+// Router is a small convenience for registering the methods of Go types as routes; paths
+// are matched exactly.
+type Router struct {
+	mu     sync.RWMutex
+	routes map[string]map[string]http.Handler
+}
+
+// Handle registers the method named methodName of recv as the handler for requests with
+// the given HTTP verb and path; see Method.HTTPHandler.  recv is inspected with Stat.
+//
+// An error wrapping ErrNotFound is returned if recv has no such method.
+func (r *Router) Handle(verb, path string, recv interface{}, methodName string) error {
+	instance := Stat(recv)
+	if instance == nil {
+		return fmt.Errorf("%w: nil receiver for %v %v", ErrNotFound, verb, path)
+	}
+	m, err := instance.Methods.Named(methodName)
+	if err != nil {
+		return fmt.Errorf("%w: %T has no method %v", err, recv, methodName)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.routes == nil {
+		r.routes = map[string]map[string]http.Handler{}
+	}
+	if r.routes[path] == nil {
+		r.routes[path] = map[string]http.Handler{}
+	}
+	r.routes[path][verb] = m.HTTPHandler()
+	return nil
+}
+
+// ServeHTTP dispatches the request to the handler registered for its method and path.  It
+// responds with http.StatusNotFound if the path is not registered and with
+// http.StatusMethodNotAllowed if the path is registered for other methods only.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.RLock()
+	verbs, ok := r.routes[req.URL.Path]
+	handler := verbs[req.Method]
+	r.mu.RUnlock()
+	if !ok {
+		http.NotFound(w, req)
+		return
+	} else if handler == nil {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	handler.ServeHTTP(w, req)
+}
@@ -0,0 +1,105 @@
+package call_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+// accounts has methods that are registered as routes.
+type accounts struct{}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (accounts) Login(post loginRequest) (map[string]string, error) {
+	if post.Password != "s3cr3t" {
+		return nil, fmt.Errorf("invalid password")
+	}
+	return map[string]string{"user": post.Username}, nil
+}
+
+func (accounts) Logout(w http.ResponseWriter, req *http.Request) {
+	fmt.Fprintf(w, "Logged out from %v", req.URL.Path)
+}
+
+func ExampleRouter() {
+	var router call.Router
+	if err := router.Handle(http.MethodPost, "/login", accounts{}, "Login"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	if err := router.Handle(http.MethodPost, "/logout", accounts{}, "Logout"); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewBufferString(`{"username":"test","password":"s3cr3t"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	fmt.Print(w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/logout", nil))
+	fmt.Println(w.Body.String())
+
+	// Output: {"user":"test"}
+	// Logged out from /logout
+}
+
+func TestRouter(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var router call.Router
+	chk.ErrorIs(router.Handle(http.MethodGet, "/", accounts{}, "Missing"), call.ErrNotFound)
+	chk.ErrorIs(router.Handle(http.MethodGet, "/", nil, "Login"), call.ErrNotFound)
+	chk.NoError(router.Handle(http.MethodPost, "/login", accounts{}, "Login"))
+	//
+	serve := func(verb, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(verb, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	chk.Equal(http.StatusNotFound, serve(http.MethodGet, "/missing", "").Code)
+	chk.Equal(http.StatusMethodNotAllowed, serve(http.MethodGet, "/login", "").Code)
+	chk.Equal(http.StatusBadRequest, serve(http.MethodPost, "/login", "{").Code)
+	// An empty body is not an error.
+	chk.Equal(http.StatusInternalServerError, serve(http.MethodPost, "/login", "").Code)
+	w := serve(http.MethodPost, "/login", `{"password":"wrong"}`)
+	chk.Equal(http.StatusInternalServerError, w.Code)
+	chk.Equal("invalid password\n", w.Body.String())
+}
+
+func TestFunc_HTTPHandler(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(req *http.Request) (string, int) {
+		return req.Method, 42
+	})
+	w := httptest.NewRecorder()
+	f.HTTPHandler().ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", nil))
+	chk.Equal("PUT\n42\n", w.Body.String())
+	// Parameters of the media type are allowed.
+	type Form struct {
+		Name string `json:"name"`
+	}
+	g := call.StatFunc(func(form Form) string { return form.Name })
+	for _, contentType := range []string{"application/json", "application/json; charset=utf-8", "Application/JSON"} {
+		w = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"name":"bob"}`))
+		req.Header.Set("Content-Type", contentType)
+		g.HTTPHandler().ServeHTTP(w, req)
+		chk.Equal("bob\n", w.Body.String(), contentType)
+	}
+}
@@ -0,0 +1,70 @@
+package call
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SetInit designates the method named name as the initializer of the receiver.  The first time
+// any other method is called after the receiver is bound, with Stat or one of the Rebind
+// methods, the initializer is called first.  Pass the empty string to remove the initializer.
+//
+// The initializer must take no arguments other than the receiver.  If it returns an error the
+// method that triggered it is not called, the error is returned in Result.Error, and the
+// initializer is attempted again on the next call.  Calling the initializer directly does not
+// count as initializing the receiver.
+//
+// The initializer runs at most once per binding even when methods are called concurrently;
+// it must not call methods of the Instance through this package.
+//
+// An error wrapping ErrNotFound is returned if there is no such method and an error wrapping
+// ErrIncompatible is returned if the method takes arguments.
+func (m *Instance) SetInit(name string) error {
+	m.mutable()
+	if name != "" {
+		init, err := m.Methods.Named(name)
+		if err != nil {
+			return fmt.Errorf("%w: %v has no method %v", err, m.receiverType, name)
+		} else if init.NumIn != 1 {
+			return fmt.Errorf("%w: initializer %v must not take arguments", ErrIncompatible, init.Pretty())
+		}
+	}
+	m.initMu.Lock()
+	defer m.initMu.Unlock()
+	m.initName, m.initDone = name, false
+	for k := range m.Methods {
+		m.Methods[k].Func.initOf = nil
+		if name != "" && m.Methods[k].Name != name {
+			m.Methods[k].Func.initOf = m
+		}
+	}
+	return nil
+}
+
+// resetInit marks the receiver as not initialized.
+func (m *Instance) resetInit() {
+	if m.initName == "" {
+		return
+	}
+	m.initMu.Lock()
+	m.initDone = false
+	m.initMu.Unlock()
+}
+
+// runInit calls the initializer unless it has already succeeded for the current receiver.
+func (m *Instance) runInit() error {
+	m.initMu.Lock()
+	defer m.initMu.Unlock()
+	if m.initDone {
+		return nil
+	}
+	init, err := m.Methods.Named(m.initName)
+	if err != nil {
+		return err
+	}
+	if err = init.Func.result(init.Func.Func.Call([]reflect.Value{m.receiverValue})).Error; err != nil {
+		return err
+	}
+	m.initDone = true
+	return nil
+}
@@ -0,0 +1,132 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+// service requires Init before its other methods are valid.
+type service struct {
+	inits int
+	fail  bool
+	conn  string
+}
+
+func (s *service) Init() error {
+	s.inits++
+	if s.fail {
+		return fmt.Errorf("init failed")
+	}
+	s.conn = "connected"
+	return nil
+}
+
+func (s *service) Status() string {
+	return s.conn
+}
+
+func (s *service) Echo(v ...string) []string {
+	return v
+}
+
+func ExampleInstance_SetInit() {
+	instance := call.Stat(&service{})
+	if err := instance.SetInit("Init"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	m, _ := instance.Methods.Named("Status") // error ignored for brevity
+	fmt.Println(m.Call(m.Args()).Values...)
+
+	// Output: connected
+}
+
+func TestInstance_SetInit(t *testing.T) {
+	chk := assert.New(t)
+	//
+	svc := &service{}
+	instance := call.Stat(svc)
+	chk.ErrorIs(instance.SetInit("Missing"), call.ErrNotFound)
+	chk.ErrorIs(instance.SetInit("Echo"), call.ErrIncompatible)
+	chk.NoError(instance.SetInit("Init"))
+	status, err := instance.Methods.Named("Status")
+	chk.NoError(err)
+	echo, err := instance.Methods.Named("Echo")
+	chk.NoError(err)
+	// Once per binding across every call path.
+	status.Call(status.Args())
+	_, err = echo.CallSpread(nil, []string{"a"})
+	chk.NoError(err)
+	var result call.Result
+	status.CallInto(status.Args(), &result)
+	chk.Equal([]interface{}{"connected"}, result.Values)
+	chk.Equal(1, svc.inits)
+	// Rebinding requires initialization again.
+	other := &service{fail: true}
+	instance.Rebind(other)
+	result = status.Call(status.Args())
+	chk.EqualError(result.Error, "init failed")
+	chk.Nil(result.Values)
+	result, err = echo.CallSpread(nil, []string{"a"})
+	chk.NoError(err)
+	chk.EqualError(result.Error, "init failed")
+	chk.Equal(2, other.inits)
+	// Failures are retried.
+	other.fail = false
+	chk.Equal([]interface{}{"connected"}, status.Call(status.Args()).Values)
+	chk.Equal(3, other.inits)
+	// Copies are initialized independently after Rebind.
+	cp := instance.Copy()
+	third := &service{}
+	cp.Rebind(third)
+	cpStatus, err := cp.Methods.Named("Status")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"connected"}, cpStatus.Call(cpStatus.Args()).Values)
+	chk.Equal(1, third.inits)
+	chk.Equal(3, other.inits)
+	// Removing the initializer.
+	chk.NoError(instance.SetInit(""))
+	instance.Rebind(&service{})
+	chk.Equal([]interface{}{""}, status.Call(status.Args()).Values)
+	// Initializers set on the template apply to every Stat.
+	cache := call.NewTypeInfoCache()
+	chk.NoError(cache.StatType(reflect.TypeOf(&service{})).SetInit("Init"))
+	for k := 0; k < 3; k++ {
+		svc = &service{}
+		instance = cache.Stat(svc)
+		status, err = instance.Methods.Named("Status")
+		chk.NoError(err)
+		chk.Equal([]interface{}{"connected"}, status.Call(status.Args()).Values)
+		chk.Equal(1, svc.inits)
+		instance.Release()
+	}
+}
+
+// valueService has a value-receiver initializer; inits is shared by copies.
+type valueService struct {
+	inits *int
+}
+
+func (s valueService) Init() error {
+	*s.inits++
+	return nil
+}
+
+func TestInstance_SetInit_RebindPointer(t *testing.T) {
+	chk := assert.New(t)
+	//
+	inits := 0
+	instance := call.Stat(valueService{inits: &inits})
+	chk.NoError(instance.SetInit("Init"))
+	chk.NoError(instance.RebindPointer(&valueService{inits: &inits}))
+	// Calling the initializer directly does not run it a second time.
+	init, err := instance.Methods.Named("Init")
+	chk.NoError(err)
+	chk.NoError(init.Call(init.Args()).Error)
+	chk.Equal(1, inits)
+}
@@ -3,6 +3,9 @@ package call
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // Instance summarizes a type and its methods.
@@ -15,6 +18,25 @@ type Instance struct {
 	receiver      interface{}
 	receiverType  reflect.Type
 	receiverValue reflect.Value
+
+	// pool is set on instances created by StatType and holds released copies.
+	pool *sync.Pool
+	// origin is set on copies returned from Stat and is the instance they were copied from.
+	origin *Instance
+	// config is the configuration of the TypeInfoCache that created the instance.
+	config *cacheConfig
+	// shadowed is the result of ShadowedMethods.
+	shadowed []string
+	// addressable is true if the receiver is a pointer created by StatAddressable; see Receiver.
+	addressable bool
+	// frozen is set by Freeze.
+	frozen bool
+
+	// initName is the name of the initializer; see SetInit.  initDone is true once the
+	// initializer has succeeded for the current receiver.
+	initName string
+	initMu   sync.Mutex
+	initDone bool
 }
 
 // Copy creates a copy of the Instance object.
@@ -23,13 +45,22 @@ type Instance struct {
 // than the original.
 //
 // Further each method in Methods will have its *Func shallow copied to a new *Func instance.
-// Mutating a Method's *Func in the copy does not affect the original.
+// Mutating a Method's *Func in the copy does not affect the original.  The copy of a frozen
+// Instance is not frozen; see Freeze.
 func (m *Instance) Copy() *Instance {
+	m.initMu.Lock()
+	initDone := m.initDone
+	m.initMu.Unlock()
 	cp := &Instance{
 		Methods:       append([]Method(nil), m.Methods...),
 		receiver:      m.receiver,
 		receiverType:  m.receiverType,
 		receiverValue: m.receiverValue,
+		config:        m.config,
+		shadowed:      m.shadowed,
+		addressable:   m.addressable,
+		initName:      m.initName,
+		initDone:      initDone,
 	}
 	for k := range cp.Methods {
 		cp.Methods[k].instance = cp
@@ -37,20 +68,396 @@ func (m *Instance) Copy() *Instance {
 		// Each method gets a copy of the embedded *Func
 		f, fnew := cp.Methods[k].Func, &Func{}
 		*fnew = *f
+		fnew.owned, fnew.frozen = nil, false
+		if fnew.initOf != nil {
+			fnew.initOf = cp
+		}
 		cp.Methods[k].Func = fnew
 
 	}
 	return cp
 }
 
+// ArgumentTypes returns the distinct argument types, excluding receivers, across every method
+// in Methods in order of first appearance.  It is intended for registration-time checks that a
+// dependency container can supply every type the methods require.
+func (m *Instance) ArgumentTypes() []reflect.Type {
+	var rv []reflect.Type
+	seen := map[reflect.Type]bool{}
+	for _, method := range m.Methods {
+		for _, T := range method.InTypes[1:] {
+			if !seen[T] {
+				seen[T] = true
+				rv = append(rv, T)
+			}
+		}
+	}
+	return rv
+}
+
+// WireableMethods returns the methods whose every non-receiver argument can be supplied
+// automatically: either its type is a key in container or Args() creates a usable value for
+// it.  Args() creates usable values for arguments that can not be nil, such as scalars and
+// structs, and for arguments with a non-nil default or an argument pool; see RisksNilPanic.
+//
+// WireableMethods is intended for routers that register exactly the handlers a dependency
+// container can satisfy.  Arguments removed by PruneIn must be in container.
+func (m *Instance) WireableMethods(container map[reflect.Type]interface{}) Methods {
+	var rv Methods
+	for _, method := range m.Methods {
+		creatable := map[int]bool{}
+		for _, arg := range method.AllArgs() {
+			creatable[arg.N] = true
+		}
+		for _, arg := range method.RisksNilPanic() {
+			creatable[arg.N] = false
+		}
+		wireable := true
+		for n := 1; n < method.NumIn && wireable; n++ {
+			_, ok := container[method.InTypes[n]]
+			wireable = ok || creatable[n]
+		}
+		if wireable {
+			rv = append(rv, method)
+		}
+	}
+	return rv
+}
+
+// Describe returns a listing of the methods and their signatures without the receiver, one
+// per line and sorted by name; it is intended for debug output such as an admin endpoint.
+//
+//	Hello(examples.Response, *examples.Request) (bool, error)
+func (m *Instance) Describe() string {
+	lines := make([]string, len(m.Methods))
+	for k, method := range m.Methods {
+		lines[k] = method.Name + method.Func.signature(1)
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// Implements returns true if the receiver type implements the interface type iface; when it
+// does not the names of the methods of iface that are missing or have a different signature
+// are returned in sorted order.  It is intended for helpful errors when registering plugins.
+//
+// If iface is not an interface type then false and no names are returned.
+func (m *Instance) Implements(iface reflect.Type) (bool, []string) {
+	if iface == nil || iface.Kind() != reflect.Interface {
+		return false, nil
+	} else if m.receiverType.Implements(iface) {
+		return true, nil
+	}
+	var names []string
+	for k, max := 0, iface.NumMethod(); k < max; k++ {
+		want := iface.Method(k)
+		have, ok := m.receiverType.MethodByName(want.Name)
+		if !ok || !sameMethodType(have.Type, want.Type) {
+			names = append(names, want.Name)
+		}
+	}
+	return false, names
+}
+
+// sameMethodType returns true if the method type have, whose first argument is the receiver,
+// has the signature of the interface method type want.
+func sameMethodType(have, want reflect.Type) bool {
+	if have.NumIn()-1 != want.NumIn() || have.NumOut() != want.NumOut() || have.IsVariadic() != want.IsVariadic() {
+		return false
+	}
+	for k := 0; k < want.NumIn(); k++ {
+		if have.In(k+1) != want.In(k) {
+			return false
+		}
+	}
+	for k := 0; k < want.NumOut(); k++ {
+		if have.Out(k) != want.Out(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// Override replaces the implementation of the method named name with fn; subsequent calls
+// through the Method, including Method values obtained earlier from this Instance, call fn.
+// It is intended for replacing a single method with a stub in tests.
+//
+// fn must have the signature of the method either with the receiver as its first argument, in
+// which case it receives the Instance's receiver, or without the receiver.  An error wrapping
+// ErrNotFound is returned if the method does not exist and an error wrapping ErrIncompatible
+// is returned if fn is not a function with a compatible signature.
+//
+// The override applies only to this Instance.  Method.Method is not changed and continues to
+// describe the original method; RebindPointer discards overrides.
+func (m *Instance) Override(name string, fn interface{}) error {
+	m.mutable()
+	k := -1
+	for n, method := range m.Methods {
+		if method.Name == name {
+			k = n
+			break
+		}
+	}
+	if k == -1 {
+		return fmt.Errorf("%w: method %v", ErrNotFound, name)
+	}
+	f := m.Methods[k].Func
+	T := f.Func.Type()
+	F := reflect.ValueOf(fn)
+	if !F.IsValid() || F.Kind() != reflect.Func || F.IsNil() {
+		return fmt.Errorf("%w: %T is not a function", ErrIncompatible, fn)
+	} else if F.Type() != T {
+		in := make([]reflect.Type, 0, T.NumIn()-1)
+		for n := 1; n < T.NumIn(); n++ {
+			in = append(in, T.In(n))
+		}
+		if F.Type() != reflect.FuncOf(in, f.OutTypes, T.IsVariadic()) {
+			return fmt.Errorf("%w: %v can not override %v", ErrIncompatible, F.Type(), m.Methods[k].Pretty())
+		}
+		stub := F
+		F = reflect.MakeFunc(T, func(args []reflect.Value) []reflect.Value {
+			if T.IsVariadic() {
+				return stub.CallSlice(args[1:])
+			}
+			return stub.Call(args[1:])
+		})
+	}
+	f.Func = F
+	return nil
+}
+
+// Receiver returns the receiver the Instance is bound to.
+//
+// For an Instance created by StatAddressable the current value of the addressable copy is
+// returned rather than the pointer; it reflects any mutations made by pointer-receiver
+// methods.  For an Instance created by Stat with a value receiver the value passed to Stat is
+// returned and mutations made by methods, which operate on copies of the receiver, are lost.
+func (m *Instance) Receiver() interface{} {
+	if m.addressable && m.receiverValue.Kind() == reflect.Ptr && !m.receiverValue.IsNil() {
+		return m.receiverValue.Elem().Interface()
+	} else if m.receiver == nil && m.receiverValue.IsValid() && m.receiverValue.CanInterface() {
+		return m.receiverValue.Interface()
+	}
+	return m.receiver
+}
+
+// ReceiverIsZero returns true if the receiver is the zero value of its type, such as the
+// receiver of the *Instance returned by TypeInfoCache.StatType or a nil pointer.
+//
+// Callers that create templates with StatType can use ReceiverIsZero to assert that Rebind
+// was called before invoking methods.
+func (m *Instance) ReceiverIsZero() bool {
+	return m.receiverValue.IsZero()
+}
+
+// ShadowedMethods returns the sorted names of methods from embedded fields of the receiver
+// type that are not promoted because the outer type declares a method of the same name
+// or because more than one embedded field provides a method of that name.
+//
+// ShadowedMethods is intended for linters and other tooling that inspect types with heavy
+// use of embedding.  Only fields embedded directly in the receiver struct are inspected.
+// A declared method is detected by comparing method sets; one with the same signature and
+// the same kind of receiver as the method it shadows is not detected.  Treat the result as
+// a diagnostic aid.
+func (m *Instance) ShadowedMethods() []string {
+	return append([]string(nil), m.shadowed...)
+}
+
+// TransformCollisions returns the wire names that transform produces from more than one
+// method name, each mapped to the colliding method names in method order; for example a
+// transform to snake case maps both GetUser and Get_user to get_user.  Routers can refuse to
+// register an Instance with collisions instead of letting one method shadow another.
+//
+// If transform is nil the current WireName of each method is used; see
+// TypeInfoCache.SetMethodNameTransform.  nil is returned if there are no collisions.
+func (m *Instance) TransformCollisions(transform func(goName string) string) map[string][]string {
+	names := map[string][]string{}
+	for _, method := range m.Methods {
+		wire := method.WireName
+		if transform != nil {
+			wire = transform(method.Name)
+		}
+		names[wire] = append(names[wire], method.Name)
+	}
+	var rv map[string][]string
+	for wire, goNames := range names {
+		if len(goNames) < 2 {
+			continue
+		} else if rv == nil {
+			rv = map[string][]string{}
+		}
+		rv[wire] = goNames
+	}
+	return rv
+}
+
+// InvokeAllNamed calls the method name with arguments from Args() and returns its Result.  If the
+// receiver has no such method, because methods of the same name from several embedded fields
+// collide and none is promoted, the method name of each field embedded directly in the
+// receiver struct is called instead in field order; it is intended for lifecycle fan-out such
+// as calling Start on every component of an aggregate.
+//
+// Embedded fields are inspected with Stat(); pointer-receiver methods are found when the
+// receiver is a pointer.  Fields that are nil or unexported are skipped.
+//
+// Every method is called and the Results are returned in call order.  Errors returned by the
+// methods are prefixed with the embedded type and returned as Errors.  An error wrapping
+// ErrNotFound is returned if nothing named name was called.
+func (m *Instance) InvokeAllNamed(name string) ([]Result, error) {
+	if method, err := m.Methods.Named(name); err == nil {
+		result := method.Call(method.Args())
+		if result.Error != nil {
+			return []Result{result}, Errors{fmt.Errorf("%v: %w", name, result.Error)}
+		}
+		return []Result{result}, nil
+	}
+	S := m.receiverValue
+	if S.Kind() == reflect.Ptr && !S.IsNil() {
+		S = S.Elem()
+	}
+	var results []Result
+	var errs Errors
+	if S.Kind() == reflect.Struct {
+		for k, max := 0, S.NumField(); k < max; k++ {
+			field := S.Type().Field(k)
+			V := S.Field(k)
+			if !field.Anonymous || !V.CanInterface() {
+				continue
+			} else if V.Kind() != reflect.Ptr && V.Kind() != reflect.Interface && V.CanAddr() {
+				V = V.Addr()
+			}
+			if (V.Kind() == reflect.Ptr || V.Kind() == reflect.Interface) && V.IsNil() {
+				continue
+			}
+			method, err := Stat(V.Interface()).Methods.Named(name)
+			if err != nil {
+				continue
+			}
+			result := method.Call(method.Args())
+			results = append(results, result)
+			if result.Error != nil {
+				errs = append(errs, fmt.Errorf("%v.%v: %w", field.Name, name, result.Error))
+			}
+		}
+	}
+	if results == nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, name)
+	} else if len(errs) > 0 {
+		return results, errs
+	}
+	return results, nil
+}
+
+// Release returns an *Instance obtained from Stat() to an internal pool; later calls to
+// Stat() for the same type may reuse it rather than allocating a new copy.
+//
+// The *Instance, its Methods, and any Method obtained from it must not be used after
+// calling Release.  When the Instance is reused its Methods are restored to their
+// original configuration; changes such as pruned arguments are not retained.
+//
+// Release is a no-op for an *Instance that did not come from Stat(), that was upgraded
+// with RebindPointer, or that was already released.
+func (m *Instance) Release() {
+	origin := m.origin
+	if origin == nil || m.receiverType != origin.receiverType || len(m.Methods) != len(origin.Methods) {
+		return
+	}
+	m.origin = nil
+	m.receiver, m.receiverValue = origin.receiver, origin.receiverValue
+	origin.pool.Put(m)
+}
+
+// reset restores the receiver and the methods of m to the same state as template.
+func (m *Instance) reset(template *Instance) {
+	m.receiver, m.receiverValue = template.receiver, template.receiverValue
+	m.initName, m.initDone = template.initName, false
+	m.addressable = template.addressable
+	for k := range m.Methods {
+		f := m.Methods[k].Func
+		*f = *template.Methods[k].Func
+		f.owned = nil
+		if f.initOf != nil {
+			f.initOf = m
+		}
+		m.Methods[k] = template.Methods[k]
+		m.Methods[k].Func, m.Methods[k].instance = f, m
+	}
+}
+
 // Rebind sets the receiver to the new value.
 //
 // If the incoming value does not have the same type as the original receiver then a panic will occur.
 func (m *Instance) Rebind(in interface{}) {
+	m.mutable()
 	v, t := reflect.ValueOf(in), reflect.TypeOf(in)
 	if t != m.receiverType {
-		panic(fmt.Sprintf("%T.Rebind expects same underlying type: original %T not compatible with incoming %T", m, m.receiver, in))
+		panic(fmt.Sprintf("%T.Rebind expects same underlying type: original %v not compatible with incoming %T", m, m.receiverType, in))
 	}
 	m.receiver = in
 	m.receiverValue = v
+	m.resetInit()
+}
+
+// RebindValue is the same as Rebind except it accepts the new receiver as a reflect.Value; it
+// is a convenience for callers that already hold the receiver as a reflect.Value.
+//
+// If v does not have the same type as the original receiver then a panic will occur.
+func (m *Instance) RebindValue(v reflect.Value) {
+	m.mutable()
+	if !v.IsValid() || v.Type() != m.receiverType {
+		panic(fmt.Sprintf("%T.RebindValue expects same underlying type: original %v not compatible with incoming %v", m, m.receiverType, v))
+	}
+	// receiver is created from receiverValue by Receiver() when needed.
+	m.receiver = nil
+	m.receiverValue = v
+	m.resetInit()
+}
+
+// RebindPointer upgrades an Instance whose receiver is a value type T to the pointer
+// type *T; in must be a *T or an error is returned.
+//
+// After RebindPointer the Instance dispatches through the pointer and Methods also
+// contains any methods declared with pointer receivers.  Methods are ordered by name
+// so rebinding is only allowed when every existing method retains its index; an
+// error wrapping ErrIncompatible is returned if a pointer-receiver method would sort
+// before an existing method.
+//
+// Per-method configuration such as pruned arguments is retained for existing methods.
+func (m *Instance) RebindPointer(in interface{}) error {
+	m.mutable()
+	v, t := reflect.ValueOf(in), reflect.TypeOf(in)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem() != m.receiverType {
+		return fmt.Errorf("%w: %T.RebindPointer expects *%v; got %T", ErrIncompatible, m, m.receiverType, in)
+	}
+	num := t.NumMethod()
+	for k, method := range m.Methods {
+		if k >= num || t.Method(k).Name != method.Name {
+			return fmt.Errorf("%w: %T.RebindPointer would change the index of method %v", ErrIncompatible, m, method.Name)
+		}
+	}
+	methods := make([]Method, num)
+	for k := 0; k < num; k++ {
+		methods[k] = newMethod(m, t.Method(k))
+		if k < len(m.Methods) {
+			// Keep the existing configuration but dispatch through the pointer type.
+			f, fnew := m.Methods[k].Func, &Func{}
+			*fnew = *f
+			fnew.Func, fnew.InKinds, fnew.InTypes = methods[k].Func.Func, methods[k].Func.InKinds, methods[k].Func.InTypes
+			methods[k].Func = fnew
+			methods[k].Kind = m.Methods[k].Kind
+		}
+	}
+	m.Methods = methods
+	m.receiver = in
+	m.receiverType = t
+	m.receiverValue = v
+	if m.initName != "" {
+		for k := range m.Methods {
+			if m.Methods[k].Name != m.initName {
+				m.Methods[k].Func.initOf = m
+			}
+		}
+	}
+	m.resetInit()
+	return nil
 }
@@ -1,6 +1,7 @@
 package call
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +21,45 @@ func TestStat_SwapReceiver(t *testing.T) {
 		chk.Equal(talk, instance.receiverValue.Interface())
 	}
 }
+
+func TestInstance_Release(t *testing.T) {
+	chk := assert.New(t)
+	//
+	cache := NewTypeInfoCache()
+	template := cache.StatType(reflect.TypeOf(examples.MapSession{}))
+	reused := false
+	for k := 0; k < 100; k++ {
+		sess := examples.MapSession{}
+		instance := cache.Stat(sess)
+		chk.Equal(template, instance.origin)
+		chk.Equal(sess, instance.receiver)
+		for n, m := range instance.Methods {
+			chk.Equal(instance, m.instance)
+			chk.Equal(template.Methods[n].InCreate, m.InCreate)
+			chk.NotSame(template.Methods[n].Func, m.Func)
+		}
+		// Mutate the instance before releasing; the mutation must not survive.
+		get, _ := instance.Methods.Named("Get")
+		get.PruneIn(reflect.TypeOf(""))
+		chk.Empty(get.InCreate)
+		instance.Release()
+		chk.Nil(instance.origin)
+		chk.Equal(template.receiver, instance.receiver)
+		instance.Release() // Second release is a no-op.
+		//
+		next := cache.Stat(examples.MapSession{})
+		if next == instance {
+			reused = true
+		}
+		next.Release()
+	}
+	chk.True(reused)
+	chk.Len(template.Methods[0].InCreate, 1)
+	//
+	// Copies and templates are never pooled.
+	for template.pool.Get() != nil {
+	}
+	template.Release()
+	template.Copy().Release()
+	chk.Nil(template.pool.Get())
+}
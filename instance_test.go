@@ -3,6 +3,8 @@ package call_test
 import (
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -132,3 +134,429 @@ func ExampleInstance_Rebind_panic() {
 	// Output: Hello!  My name is Bob and I am 40 year(s) old.
 	// Rebind panics because types are not the same.
 }
+
+// backwards has a pointer-receiver method that sorts before its value-receiver method.
+type backwards struct{}
+
+func (b *backwards) A() {}
+func (b backwards) B()  {}
+
+func ExampleInstance_RebindPointer() {
+	var counter examples.Counter
+	instance := call.Stat(counter)
+	for _, m := range instance.Methods {
+		fmt.Println(m.Name)
+	}
+
+	c := &examples.Counter{N: 10}
+	if err := instance.RebindPointer(c); err != nil {
+		fmt.Println(err)
+		return
+	}
+	incr, _ := instance.Methods.Named("Incr") // error ignored for brevity
+	incr.Call(incr.Args())
+	count, _ := instance.Methods.Named("Count") // error ignored for brevity
+	fmt.Println(count.Call(count.Args()).Values[0], c.N)
+
+	// Output: Count
+	// 11 11
+}
+
+func TestInstance_RebindPointer_Errors(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var counter examples.Counter
+	instance := call.Stat(counter)
+	err := instance.RebindPointer(counter)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	err = instance.RebindPointer(&examples.Person{})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	//
+	instance = call.Stat(backwards{})
+	err = instance.RebindPointer(&backwards{})
+	chk.ErrorIs(err, call.ErrIncompatible)
+	chk.Len(instance.Methods, 1)
+	//
+	// Pruned arguments are retained when upgrading to the pointer.
+	instance = call.Stat(examples.MapSession{})
+	get, _ := instance.Methods.Named("Get")
+	get.PruneIn(reflect.TypeOf(""))
+	chk.NoError(instance.RebindPointer(&examples.MapSession{}))
+	get, _ = instance.Methods.Named("Get")
+	chk.Empty(get.InCreate)
+	chk.Equal(reflect.TypeOf(&examples.MapSession{}), get.InTypes[0])
+}
+
+func ExampleInstance_RebindValue() {
+	people := reflect.ValueOf([]examples.Person{
+		{Name: "Bob", Age: 40},
+		{Name: "Sally", Age: 30},
+	})
+	instance := call.TypeCache.StatType(reflect.TypeOf(examples.Person{})).Copy()
+	greet, _ := instance.Methods.Named("Greet") // error ignored for brevity
+	for k := 0; k < people.Len(); k++ {
+		instance.RebindValue(people.Index(k))
+		fmt.Println(greet.Call(greet.Args()).Values[0])
+	}
+
+	// Output: Hello!  My name is Bob and I am 40 year(s) old.
+	// Hello!  My name is Sally and I am 30 year(s) old.
+}
+
+func TestInstance_RebindValue_Panics(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(&examples.Person{})
+	chk.Panics(func() { instance.RebindValue(reflect.ValueOf(examples.Person{})) })
+	chk.Panics(func() { instance.RebindValue(reflect.Value{}) })
+	chk.NotPanics(func() { instance.RebindValue(reflect.ValueOf(&examples.Person{})) })
+	// The receiver reflects the value.
+	bob := &examples.Person{Name: "Bob"}
+	instance.RebindValue(reflect.ValueOf(bob))
+	chk.Same(bob, instance.Receiver())
+}
+
+func BenchmarkInstance_Rebind(b *testing.B) {
+	people := make([]examples.Person, 64)
+	instance := call.Stat(&people[0])
+	greet, _ := instance.Methods.Named("Greet")
+	b.Run("Rebind", func(b *testing.B) {
+		b.ReportAllocs()
+		for k := 0; k < b.N; k++ {
+			V := reflect.ValueOf(&people[k%len(people)])
+			instance.Rebind(V.Interface())
+			greet.Call(greet.Args())
+		}
+	})
+	b.Run("RebindValue", func(b *testing.B) {
+		b.ReportAllocs()
+		for k := 0; k < b.N; k++ {
+			V := reflect.ValueOf(&people[k%len(people)])
+			instance.RebindValue(V)
+			greet.Call(greet.Args())
+		}
+	})
+}
+
+func TestInstance_ReceiverIsZero(t *testing.T) {
+	chk := assert.New(t)
+	//
+	template := call.TypeCache.StatType(reflect.TypeOf(&examples.Counter{}))
+	chk.True(template.ReceiverIsZero())
+	instance := template.Copy()
+	instance.Rebind(&examples.Counter{})
+	chk.False(instance.ReceiverIsZero())
+	chk.True(template.Methods.ReceiverIsZero())
+	chk.False(instance.Methods.ReceiverIsZero())
+	chk.False(call.Methods{}.ReceiverIsZero())
+	//
+	chk.True(call.Stat(examples.Counter{}).ReceiverIsZero())
+	chk.False(call.Stat(examples.Counter{N: 1}).ReceiverIsZero())
+}
+
+func TestInstance_Implements(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Plugin interface {
+		Close() error
+		Join(prefix string) string
+		Pair(a, b string) string
+	}
+	type Pairer interface {
+		Pair(a, b string) string
+	}
+	instance := call.Stat(joiner{})
+	ok, names := instance.Implements(reflect.TypeOf((*Plugin)(nil)).Elem())
+	chk.False(ok)
+	chk.Equal([]string{"Close", "Join"}, names)
+	ok, names = instance.Implements(reflect.TypeOf((*Pairer)(nil)).Elem())
+	chk.True(ok)
+	chk.Empty(names)
+	ok, names = instance.Implements(reflect.TypeOf(joiner{}))
+	chk.False(ok)
+	chk.Empty(names)
+	ok, _ = instance.Implements(reflect.TypeOf((*fmt.Stringer)(nil)).Elem())
+	chk.False(ok)
+}
+
+func TestInstance_ArgumentTypes(t *testing.T) {
+	chk := assert.New(t)
+	//
+	chk.Equal([]reflect.Type{reflect.TypeOf(""), reflect.TypeOf([]string(nil))}, call.Stat(joiner{}).ArgumentTypes())
+	types := call.Stat(examples.ManyArgs{}).ArgumentTypes()
+	chk.Equal([]reflect.Type{
+		reflect.TypeOf((*examples.Response)(nil)).Elem(),
+		reflect.TypeOf(&examples.Request{}),
+		reflect.TypeOf((*examples.Session)(nil)).Elem(),
+	}, types)
+	chk.Empty(call.Stat(examples.Counter{}).ArgumentTypes())
+}
+
+func ExampleInstance_Describe() {
+	fmt.Println(call.Stat(examples.Talker{}).Describe())
+
+	// Output: Error(examples.Response, *examples.Request) error
+	// Goodbye(*examples.Request, struct { StringField string; NumField int })
+	// Hello(examples.Response, *examples.Request) (bool, error)
+}
+
+func TestInstance_WireableMethods(t *testing.T) {
+	chk := assert.New(t)
+	//
+	names := func(methods call.Methods) []string {
+		var rv []string
+		for _, m := range methods {
+			rv = append(rv, m.Name)
+		}
+		return rv
+	}
+	instance := call.Stat(examples.Talker{})
+	// *examples.Request is nil when created and examples.Response is an interface.
+	chk.Empty(instance.WireableMethods(nil))
+	container := map[reflect.Type]interface{}{
+		reflect.TypeOf(&examples.Request{}): &examples.Request{},
+	}
+	chk.Equal([]string{"Goodbye"}, names(instance.WireableMethods(container)))
+	container[reflect.TypeOf((*examples.Response)(nil)).Elem()] = nil
+	chk.Equal([]string{"Error", "Goodbye", "Hello"}, names(instance.WireableMethods(container)))
+	// Defaults make arguments creatable.
+	m, err := instance.Methods.Named("Goodbye")
+	chk.NoError(err)
+	chk.NoError(m.SetDefault(1, &examples.Request{}))
+	chk.Equal([]string{"Goodbye"}, names(instance.WireableMethods(nil)))
+}
+
+func ExampleInstance_Override() {
+	instance := call.Stat(&examples.Person{Name: "Bob", Age: 40})
+	err := instance.Override("Greet", func() string {
+		return "stubbed"
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	m, _ := instance.Methods.Named("Greet") // error ignored for brevity
+	fmt.Println(m.Call(m.Args()).Values[0])
+
+	// Output: stubbed
+}
+
+func TestInstance_Override(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(joiner{sep: "-"})
+	m, err := instance.Methods.Named("Pair")
+	chk.NoError(err)
+	// With the receiver.
+	chk.NoError(instance.Override("Pair", func(j joiner, a, b string) string {
+		return b + j.sep + a
+	}))
+	result, err := m.CallValues("a", "b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"b-a"}, result.Values)
+	// Variadic without the receiver.
+	chk.NoError(instance.Override("Join", func(prefix string, parts ...string) string {
+		return fmt.Sprint(prefix, len(parts))
+	}))
+	m, err = instance.Methods.Named("Join")
+	chk.NoError(err)
+	result, err = m.CallSpread([]interface{}{"n="}, []string{"x", "y"})
+	chk.NoError(err)
+	chk.Equal([]interface{}{"n=2"}, result.Values)
+	// Other instances are not affected.
+	result, _, err = call.TryInvoke(joiner{sep: "-"}, "Pair", "a", "b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	//
+	chk.ErrorIs(instance.Override("Missing", func() {}), call.ErrNotFound)
+	chk.ErrorIs(instance.Override("Pair", func(a string) string { return a }), call.ErrIncompatible)
+	chk.ErrorIs(instance.Override("Pair", nil), call.ErrIncompatible)
+	chk.ErrorIs(instance.Override("Pair", (func(a, b string) string)(nil)), call.ErrIncompatible)
+}
+
+func ExampleStatAddressable() {
+	counter := examples.Counter{N: 1}
+	instance := call.StatAddressable(counter)
+	incr, _ := instance.Methods.Named("Incr") // error ignored for brevity
+	incr.Call(incr.Args())
+	incr.Call(incr.Args())
+	fmt.Println(instance.Receiver(), counter)
+
+	// Output: {3} {1}
+}
+
+func TestInstance_Receiver(t *testing.T) {
+	chk := assert.New(t)
+	//
+	counter := examples.Counter{N: 1}
+	// Methods of a value receiver operate on copies.
+	instance := call.Stat(counter)
+	_, err := instance.Methods.Named("Incr")
+	chk.ErrorIs(err, call.ErrNotFound)
+	chk.Equal(counter, instance.Receiver())
+	// The addressable copy is mutated.
+	instance = call.StatAddressable(counter)
+	incr, err := instance.Methods.Named("Incr")
+	chk.NoError(err)
+	incr.Call(incr.Args())
+	chk.Equal(examples.Counter{N: 2}, instance.Receiver())
+	chk.Equal(examples.Counter{N: 2}, instance.Copy().Receiver())
+	chk.Equal(1, counter.N)
+	// Pointers are used as-is.
+	instance = call.StatAddressable(&counter)
+	incr, err = instance.Methods.Named("Incr")
+	chk.NoError(err)
+	incr.Call(incr.Args())
+	chk.Equal(examples.Counter{N: 2}, instance.Receiver())
+	chk.Equal(2, counter.N)
+	// Released instances do not keep the addressable flag.
+	instance.Release()
+	chk.Equal(&counter, call.Stat(&counter).Receiver())
+	chk.Nil(call.StatAddressable(nil))
+}
+
+// Engine, Radio, and Lights are components of car; their Start methods collide.
+type Engine struct{ started bool }
+
+func (e *Engine) Start() error {
+	e.started = true
+	return nil
+}
+
+type Radio struct{ Station string }
+
+func (r Radio) Start() error {
+	if r.Station == "" {
+		return fmt.Errorf("no station")
+	}
+	return nil
+}
+
+type Lights struct{}
+
+func (Lights) Start() error { return nil }
+
+type car struct {
+	*Engine
+	Radio
+	*Lights
+}
+
+func TestInstance_InvokeAllNamed(t *testing.T) {
+	chk := assert.New(t)
+	//
+	c := &car{Engine: &Engine{}, Radio: Radio{Station: "fm"}}
+	results, err := call.Stat(c).InvokeAllNamed("Start")
+	chk.NoError(err)
+	// Lights is nil and skipped.
+	chk.Len(results, 2)
+	chk.True(c.Engine.started)
+	//
+	c = &car{Engine: &Engine{}, Lights: &Lights{}}
+	results, err = call.Stat(c).InvokeAllNamed("Start")
+	chk.Len(results, 3)
+	chk.EqualError(err, "Radio.Start: no station")
+	//
+	// A method in the method set is called by itself.
+	results, err = call.Stat(c.Engine).InvokeAllNamed("Start")
+	chk.NoError(err)
+	chk.Len(results, 1)
+	results, err = call.Stat(Radio{}).InvokeAllNamed("Start")
+	chk.Len(results, 1)
+	chk.EqualError(err, "Start: no station")
+	//
+	_, err = call.Stat(c).InvokeAllNamed("Stop")
+	chk.ErrorIs(err, call.ErrNotFound)
+}
+
+// users has methods that collide under an aggressive name transform.
+type users struct{}
+
+func (users) GetUser() string   { return "GetUser" }
+func (users) Get_user() string  { return "Get_user" }
+func (users) Getuser() string   { return "Getuser" }
+func (users) ListUsers() string { return "ListUsers" }
+
+func TestInstance_TransformCollisions(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(users{})
+	chk.Nil(instance.TransformCollisions(nil))
+	chk.Nil(instance.TransformCollisions(func(name string) string { return "/" + name }))
+	chk.Equal(map[string][]string{"GETUSER": {"GetUser", "Getuser"}}, instance.TransformCollisions(strings.ToUpper))
+	lower := func(name string) string {
+		return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	}
+	chk.Equal(map[string][]string{"getuser": {"GetUser", "Get_user", "Getuser"}}, instance.TransformCollisions(lower))
+	// The current wire names are used when transform is nil.
+	cache := call.NewTypeInfoCache()
+	cache.SetMethodNameTransform(lower)
+	chk.Equal(map[string][]string{"getuser": {"GetUser", "Get_user", "Getuser"}}, cache.Stat(users{}).TransformCollisions(nil))
+}
+
+func TestInstance_Freeze(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(joiner{sep: "-"})
+	m, err := instance.Methods.Named("Pair")
+	chk.NoError(err)
+	chk.NoError(m.SetDefault(1, "x"))
+	frozen := instance.Freeze()
+	fm, err := frozen.Methods.Named("Pair")
+	chk.NoError(err)
+	// Calling and introspection remain available.
+	chk.Equal([]interface{}{"x-"}, fm.Call(fm.Args()).Values)
+	chk.Equal(m.Pretty(), fm.Pretty())
+	chk.NotEmpty(frozen.Describe())
+	result, err := fm.CallWith(joiner{sep: "+"}, fm.Args())
+	chk.NoError(err)
+	chk.Equal([]interface{}{"x+"}, result.Values)
+	//
+	chk.Panics(func() { frozen.Rebind(joiner{}) })
+	chk.Panics(func() { frozen.RebindValue(reflect.ValueOf(joiner{})) })
+	chk.Panics(func() { _ = frozen.RebindPointer(&joiner{}) })
+	chk.Panics(func() { _ = frozen.Override("Pair", func(a, b string) string { return "" }) })
+	chk.Panics(func() { _ = frozen.SetInit("") })
+	chk.Panics(func() { frozen.ClassifyMethods(func(call.Method) call.MethodKind { return 0 }) })
+	chk.Panics(func() { fm.PruneIn(reflect.TypeOf("")) })
+	chk.Panics(func() { _ = fm.SetDefault(1, "y") })
+	chk.Panics(func() { _ = fm.SetArgNames("a", "b") })
+	chk.Panics(func() { fm.SetReadOnly(true) })
+	chk.Panics(func() { fm.TimeCalls(true) })
+	// The original and copies of the frozen Instance are not frozen.
+	chk.NotPanics(func() { m.PruneIn(reflect.TypeOf("")) })
+	cp := frozen.Copy()
+	chk.NotPanics(func() { cp.Rebind(joiner{sep: "+"}) })
+	cm, _ := cp.Methods.Named("Pair")
+	chk.NotPanics(func() { cm.TimeCalls(true) })
+	chk.Equal([]interface{}{"x-"}, fm.Call(fm.Args()).Values)
+}
+
+func TestInstance_Freeze_SingleThreaded(t *testing.T) {
+	chk := assert.New(t)
+	//
+	// Run with -race; frozen methods do not share the *Args of a single-threaded Func.
+	instance := call.Stat(joiner{sep: "-"})
+	m, err := instance.Methods.Named("Pair")
+	chk.NoError(err)
+	m.SetSingleThreaded(true)
+	fm, err := instance.Freeze().Methods.Named("Pair")
+	chk.NoError(err)
+	var wg sync.WaitGroup
+	results := make([]interface{}, 8)
+	for k := range results {
+		wg.Add(1)
+		go func(k int) {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				args := fm.Args()
+				*args.Pointers[1].(*string) = fmt.Sprint(k)
+				results[k] = fm.Call(args).Values[0]
+			}
+		}(k)
+	}
+	wg.Wait()
+	for k, result := range results {
+		chk.Equal(fmt.Sprint(k, "-"), result)
+	}
+}
@@ -0,0 +1,57 @@
+//go:build go1.21
+// +build go1.21
+
+package call
+
+import (
+	"fmt"
+)
+
+// Invoke calls the method name of i with vals as with Method.CallValues and returns the first
+// return value that is not an error as R along with the error returned by the method.
+//
+// An error wrapping ErrNotFound and the zero R are returned if the method does not exist.  An
+// error wrapping ErrIncompatible is returned if vals do not match the method, in which case the
+// method is not called, or if the first non-error return value is not an R.  If the method has
+// no non-error return values the zero R is returned.  A nil return value is returned as the
+// zero R.
+func Invoke[R any](i *Instance, name string, vals ...interface{}) (R, error) {
+	var rv R
+	m, err := i.Methods.Named(name)
+	if err != nil {
+		return rv, fmt.Errorf("%w: method %v", err, name)
+	}
+	result, err := m.CallValues(vals...)
+	if err != nil {
+		return rv, err
+	}
+	rv, ok, err := unwrap[R](result)
+	if !ok {
+		return rv, fmt.Errorf("%v: %w", m.Name, err)
+	}
+	return rv, err
+}
+
+// Unwrap is the same as Result.Unwrap except the value is returned as T; a nil value, or no
+// value, is returned as the zero T.
+//
+// An error wrapping ErrIncompatible and the zero T are returned if the value is not a T.
+func Unwrap[T any](r Result) (T, error) {
+	rv, _, err := unwrap[T](r)
+	return rv, err
+}
+
+// unwrap is Unwrap except ok is false when err is the ErrIncompatible error created by unwrap
+// rather than the error returned by the function.  Errors returned by functions can not be
+// compared to tell them apart since they may be uncomparable types such as Errors.
+func unwrap[T any](r Result) (rv T, ok bool, err error) {
+	v, err := r.Unwrap()
+	if v == nil {
+		return rv, true, err
+	}
+	typed, ok := v.(T)
+	if !ok {
+		return rv, false, fmt.Errorf("%w: result is %T and not %T", ErrIncompatible, v, rv)
+	}
+	return typed, true, err
+}
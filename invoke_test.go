@@ -0,0 +1,89 @@
+//go:build go1.21
+// +build go1.21
+
+package call_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleInvoke() {
+	instance := call.Stat(joiner{sep: "-"})
+	s, err := call.Invoke[string](instance, "Pair", "a", "b")
+	fmt.Println(s, err)
+
+	// Output: a-b <nil>
+}
+
+func TestInvoke(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(joiner{sep: "-"})
+	// The first non-error return value is returned with the error.
+	s, err := call.Invoke[string](instance, "Split", "a-b")
+	chk.NoError(err)
+	chk.Equal("a", s)
+	s, err = call.Invoke[string](instance, "Split", "ab")
+	chk.Error(err)
+	chk.Equal("", s)
+	//
+	p, err := call.Invoke[pair](instance, "Parts", "a", "b")
+	chk.NoError(err)
+	chk.Equal(pair{A: "a-", B: "b"}, p)
+	_, err = call.Invoke[int](instance, "Pair", "a", "b")
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = call.Invoke[string](instance, "Pair", 1)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = call.Invoke[string](instance, "Missing")
+	chk.ErrorIs(err, call.ErrNotFound)
+	// Methods without non-error returns give the zero R.
+	n, err := call.Invoke[int](call.Stat(examples.Talker{}), "Error", nil, nil)
+	chk.Error(err)
+	chk.Zero(n)
+	// Uncomparable error types are returned as is.
+	instance = call.Stat(validator{})
+	s, err = call.Invoke[string](instance, "Validate", "a")
+	chk.Equal("a", s)
+	chk.IsType(call.Errors{}, err)
+}
+
+// validator has a method returning the uncomparable error type Errors.
+type validator struct{}
+
+func (v validator) Validate(s string) (string, call.Errors) {
+	return s, call.Errors{fmt.Errorf("invalid: %v", s), fmt.Errorf("too short")}
+}
+
+func TestUnwrap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(n int) (*int, error) {
+		if n == 0 {
+			return nil, fmt.Errorf("zero")
+		}
+		return &n, nil
+	})
+	result, err := f.CallValues(2)
+	chk.NoError(err)
+	p, err := call.Unwrap[*int](result)
+	chk.NoError(err)
+	chk.Equal(2, *p)
+	result, _ = f.CallValues(0)
+	p, err = call.Unwrap[*int](result)
+	chk.EqualError(err, "zero")
+	chk.Nil(p)
+	//
+	s, err := call.Unwrap[string](result)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	chk.Equal("", s)
+	// Functions without a non-error return value give the zero T.
+	s, err = call.Unwrap[string](call.Result{Error: fmt.Errorf("zero")})
+	chk.EqualError(err, "zero")
+	chk.Equal("", s)
+}
@@ -0,0 +1,170 @@
+package call
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// decodeJSONArray decodes the elements of the JSON array params, in order, into the arguments
+// of args that have an entry in Pointers.
+func decodeJSONArray(args *Args, params json.RawMessage) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(params, &elems); err != nil {
+		return err
+	}
+	targets := 0
+	for _, pointer := range args.Pointers {
+		if pointer != nil {
+			targets++
+		}
+	}
+	if len(elems) > targets {
+		return fmt.Errorf("%w: expected at most %v params; got %v", ErrIncompatible, targets, len(elems))
+	}
+	for k, n := 0, 0; n < len(elems); k++ {
+		if args.Pointers[k] == nil {
+			continue
+		}
+		if err := json.Unmarshal(elems[n], args.Pointers[k]); err != nil {
+			return &ArgError{N: k, Err: err}
+		}
+		n++
+	}
+	return nil
+}
+
+// decodeJSONObject decodes the members of the JSON object params into the arguments of args
+// by matching member names to the names registered with SetArgNames.
+func (f *Func) decodeJSONObject(args *Args, params json.RawMessage) error {
+	if f.argNames == nil {
+		return fmt.Errorf("%w: %v has no registered argument names", ErrNotFound, f.Pretty())
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(params, &members); err != nil {
+		return err
+	}
+	for k, name := range f.argNames {
+		if name == "" || args.Pointers[k] == nil {
+			continue
+		}
+		raw, ok := members[name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, args.Pointers[k]); err != nil {
+			return &ArgError{N: k, Err: err}
+		}
+	}
+	return nil
+}
+
+// SetArgNames registers a name for each of the function's arguments; names[k] is the name
+// of argument k.  An empty name leaves its argument unnamed.  Go's reflect package does not
+// expose parameter names so they must be registered before calling CallJSONObject.
+//
+// An error wrapping ErrIncompatible is returned if len(names) is not NumIn.
+func (f *Func) SetArgNames(names ...string) error {
+	f.mutable()
+	if len(names) != f.NumIn {
+		return fmt.Errorf("%w: %v expects %v argument names; got %v", ErrIncompatible, f.Pretty(), f.NumIn, len(names))
+	}
+	f.argNames = append([]string(nil), names...)
+	return nil
+}
+
+// SetArgName registers name for the argument at index; it is the same as SetArgNames for a
+// single argument and leaves the names of other arguments unchanged.  An empty name leaves the
+// argument unnamed.
+//
+// An error wrapping ErrNotFound is returned if index is out of range.
+func (f *Func) SetArgName(index int, name string) error {
+	f.mutable()
+	if index < 0 || index >= f.NumIn {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	}
+	names := make([]string, f.NumIn)
+	copy(names, f.argNames)
+	names[index] = name
+	f.argNames = names
+	return nil
+}
+
+// CallJSONArray creates arguments with Args(), decodes the JSON array params into them by
+// position, and then invokes the function with Call().  This is the "params by position"
+// convention of JSON-RPC.
+//
+// The elements of params are assigned in order to the arguments that have an entry in
+// Pointers; arguments without a pointer, such as interfaces, are skipped and keep the
+// value given to them by Args().  params may have fewer elements than there are arguments
+// in which case the remaining arguments keep their zero values.
+//
+// An error is returned if params is not a JSON array, has too many elements, or an element
+// can not be decoded into its argument; the function is not called in that case.
+func (f *Func) CallJSONArray(params json.RawMessage) (Result, error) {
+	return f.callJSON(f.Args(), params, decodeJSONArray)
+}
+
+// CallJSONObject creates arguments with Args(), decodes the JSON object params into them by
+// argument name, and then invokes the function with Call().  This is the "params by name"
+// convention of JSON-RPC.
+//
+// Argument names must first be registered with SetArgNames; without them CallJSONObject
+// returns an error wrapping ErrNotFound.
+//
+// Each member of params is decoded into the argument with the same name.  Members without a
+// matching argument are ignored and arguments without a matching member keep their zero
+// values.  Named arguments that have no entry in Pointers, such as interfaces, can not be
+// decoded into; they keep the value given to them by Args() and their members are ignored.
+//
+// An error is returned if params is not a JSON object or a member can not be decoded into
+// its argument; the function is not called in that case.
+func (f *Func) CallJSONObject(params json.RawMessage) (Result, error) {
+	return f.callJSON(f.Args(), params, f.decodeJSONObject)
+}
+
+// callJSON decodes params into args with decode and then invokes the function; args are
+// returned to the pool if decode fails or panics.
+func (f *Func) callJSON(args *Args, params json.RawMessage, decode func(*Args, json.RawMessage) error) (Result, error) {
+	if err := decodeArgs(args, func() error { return decode(args, params) }); err != nil {
+		return Result{}, err
+	}
+	return f.Call(args), nil
+}
+
+// ZeroArgJSON returns the zero value of the struct argument at index marshaled with
+// encoding/json; it is a template payload showing the field names and types of the argument
+// and is intended for generating example request bodies in documentation.
+//
+// The argument must be a struct or pointer-to-struct; for a pointer-to-struct the zero struct
+// is marshaled rather than null.  An error wrapping ErrNotFound is returned if index is out of
+// range and an error wrapping ErrIncompatible is returned for arguments of other types.
+func (f *Func) ZeroArgJSON(index int) ([]byte, error) {
+	if index < 0 || index >= f.NumIn {
+		return nil, fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	}
+	T := f.InTypes[index]
+	if T.Kind() == reflect.Ptr {
+		T = T.Elem()
+	}
+	if T.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: argument %v is %v and not a struct", ErrIncompatible, index, f.InTypes[index])
+	}
+	return json.Marshal(reflect.New(T).Interface())
+}
+
+// UnmarshalJSONInto unmarshals data into Pointers[index] with encoding/json.  Errors from
+// unmarshaling are wrapped in an *ArgError; an error wrapping ErrNotFound is returned if the
+// argument does not have an entry in Pointers.
+//
+// The caller remains responsible for args; see Release for releasing args that are not passed
+// to Call() when decoding fails or panics.
+func (args *Args) UnmarshalJSONInto(index int, data []byte) error {
+	p, err := args.pointer(index)
+	if err != nil {
+		return err
+	} else if err = json.Unmarshal(data, p); err != nil {
+		return &ArgError{N: index, Err: err}
+	}
+	return nil
+}
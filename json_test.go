@@ -0,0 +1,225 @@
+package call_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+// calculator is used to demonstrate JSON-RPC style calls.
+type calculator struct{}
+
+func (calculator) Scale(factor int, sess examples.Session, point struct{ X, Y int }) (int, int) {
+	return factor * point.X, factor * point.Y
+}
+
+func ExampleMethod_CallJSONArray() {
+	m, _ := call.Stat(calculator{}).Methods.Named("Scale") // error ignored for brevity
+	// The Session interface argument has no pointer and is skipped.
+	result, err := m.CallJSONArray(json.RawMessage(`[3, {"X": 1, "Y": 2}]`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Values...)
+
+	// Output: 3 6
+}
+
+func TestFunc_CallJSONArray(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(s string, n int) string {
+		return fmt.Sprintf("%v=%v", s, n)
+	})
+	result, err := f.CallJSONArray(json.RawMessage(`["a", 1]`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a=1"}, result.Values)
+	// Trailing params are optional.
+	result, err = f.CallJSONArray(json.RawMessage(`["b"]`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"b=0"}, result.Values)
+	//
+	_, err = f.CallJSONArray(json.RawMessage(`["a", 1, 2]`))
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = f.CallJSONArray(json.RawMessage(`{"a": 1}`))
+	chk.Error(err)
+	_, err = f.CallJSONArray(json.RawMessage(`["a", "b"]`))
+	var argErr *call.ArgError
+	if chk.True(errors.As(err, &argErr)) {
+		chk.Equal(1, argErr.N)
+	}
+}
+
+func ExampleMethod_CallJSONObject() {
+	m, _ := call.Stat(calculator{}).Methods.Named("Scale") // error ignored for brevity
+	if err := m.SetArgNames("factor", "session", "point"); err != nil {
+		fmt.Println(err)
+		return
+	}
+	result, err := m.CallJSONObject(json.RawMessage(`{"point": {"X": 2, "Y": 5}, "factor": 10}`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Values...)
+
+	// Output: 20 50
+}
+
+func TestFunc_CallJSONObject(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(s string, sess examples.Session, n int) string {
+		return fmt.Sprintf("%v=%v %v", s, n, sess == nil)
+	})
+	// Without names.
+	_, err := f.CallJSONObject(json.RawMessage(`{"s": "a"}`))
+	chk.ErrorIs(err, call.ErrNotFound)
+	//
+	chk.ErrorIs(f.SetArgNames("s"), call.ErrIncompatible)
+	chk.NoError(f.SetArgNames("s", "sess", "n"))
+	// The interface has no pointer; its member is ignored and it is left nil.
+	result, err := f.CallJSONObject(json.RawMessage(`{"s": "a", "n": 1, "sess": {}, "extra": true}`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a=1 true"}, result.Values)
+	// Missing members keep their zero values.
+	result, err = f.CallJSONObject(json.RawMessage(`{"s": "b"}`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"b=0 true"}, result.Values)
+	//
+	_, err = f.CallJSONObject(json.RawMessage(`["a"]`))
+	chk.Error(err)
+	_, err = f.CallJSONObject(json.RawMessage(`{"n": "x"}`))
+	var argErr *call.ArgError
+	if chk.True(errors.As(err, &argErr)) {
+		chk.Equal(2, argErr.N)
+	}
+}
+
+func TestMethod_SetArgNames(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(calculator{})
+	m, err := instance.Methods.Named("Scale")
+	chk.NoError(err)
+	chk.ErrorIs(m.SetArgNames("factor", "session", "point", "receiver"), call.ErrIncompatible)
+	chk.NoError(m.SetArgNames("factor", "", "point"))
+	// Names are not shared with other instances.
+	other, err := call.Stat(calculator{}).Methods.Named("Scale")
+	chk.NoError(err)
+	_, err = other.CallJSONObject(json.RawMessage(`{"factor": 1}`))
+	chk.ErrorIs(err, call.ErrNotFound)
+}
+
+func TestMethod_SetArgName(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(calculator{}).Methods.Named("Scale")
+	chk.NoError(err)
+	chk.NoError(m.SetArgName(0, "factor"))
+	chk.NoError(m.SetArgName(2, "point"))
+	chk.ErrorIs(m.SetArgName(3, "other"), call.ErrNotFound)
+	chk.ErrorIs(m.SetArgName(-1, "receiver"), call.ErrNotFound)
+	result, err := m.CallJSONObject(json.RawMessage(`{"factor": 2, "point": {"X": 1, "Y": 2}}`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{2, 4}, result.Values)
+	// A bound method has no receiver.
+	bound := call.StatBoundMethod(calculator{}.Scale, "Scale")
+	chk.NoError(bound.SetArgName(0, "factor"))
+	chk.NoError(bound.SetArgName(2, "point"))
+	chk.ErrorIs(bound.SetArgName(3, "other"), call.ErrNotFound)
+	result, err = bound.CallJSONObject(json.RawMessage(`{"factor": 3, "point": {"X": 1, "Y": 2}}`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{3, 6}, result.Values)
+}
+
+// explosive panics when decoded.
+type explosive struct{}
+
+func (*explosive) UnmarshalJSON([]byte) error {
+	panic("boom")
+}
+
+func TestFunc_CallJSONArray_PanicReleasesArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Buffer struct{ B []byte }
+	var gets, puts int
+	f := call.StatFunc(func(buf Buffer, e explosive) {})
+	f.SetArgPool(reflect.TypeOf(Buffer{}), func() reflect.Value {
+		gets++
+		return reflect.ValueOf(Buffer{})
+	}, func(reflect.Value) {
+		puts++
+	})
+	for k := 0; k < 100; k++ {
+		chk.PanicsWithValue("boom", func() {
+			_, _ = f.CallJSONArray(json.RawMessage(`[{}, {}]`))
+		})
+	}
+	// Every *Args was released despite the panics.
+	chk.Equal(100, gets)
+	chk.Equal(100, puts)
+	// DecodeAndCall and Release also release the arguments.
+	chk.Panics(func() {
+		_, _ = f.DecodeAndCall([]byte(`{}`), time.Minute)
+	})
+	f.Args().Release()
+	chk.Equal(102, puts)
+}
+
+func TestArgs_UnmarshalJSONInto(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(n int, sess examples.Session) int { return n })
+	args := f.Args()
+	chk.NoError(args.UnmarshalJSONInto(0, []byte(`42`)))
+	var argErr *call.ArgError
+	if chk.ErrorAs(args.UnmarshalJSONInto(0, []byte(`"x"`)), &argErr) {
+		chk.Equal(0, argErr.N)
+	}
+	chk.ErrorIs(args.UnmarshalJSONInto(1, []byte(`{}`)), call.ErrNotFound)
+	chk.Equal([]interface{}{42}, f.Call(args).Values)
+}
+
+func ExampleFunc_ZeroArgJSON() {
+	type CreateUser struct {
+		Name  string   `json:"name"`
+		Age   int      `json:"age"`
+		Admin bool     `json:"admin"`
+		Tags  []string `json:"tags"`
+	}
+	handler := func(sess examples.Session, body *CreateUser) error { return nil }
+
+	payload, err := call.StatFunc(handler).ZeroArgJSON(1)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(string(payload))
+
+	// Output: {"name":"","age":0,"admin":false,"tags":null}
+}
+
+func TestFunc_ZeroArgJSON(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Point struct{ X, Y int }
+	f := call.StatFunc(func(p Point, n int) {})
+	payload, err := f.ZeroArgJSON(0)
+	chk.NoError(err)
+	chk.JSONEq(`{"X":0,"Y":0}`, string(payload))
+	_, err = f.ZeroArgJSON(1)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	_, err = f.ZeroArgJSON(2)
+	chk.ErrorIs(err, call.ErrNotFound)
+}
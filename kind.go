@@ -0,0 +1,40 @@
+package call
+
+// MethodKind classifies a Method as reading or modifying state; see Instance.ClassifyMethods.
+//
+// Go can not express whether a method is read-only so the classification is supplied by the
+// caller, usually by convention such as a method name prefix.
+type MethodKind int
+
+const (
+	// MethodUnclassified is the Kind of a Method that has not been classified.
+	MethodUnclassified MethodKind = iota
+	// MethodQuery is a read-only, idempotent method whose results may be cached.
+	MethodQuery
+	// MethodMutation is a method that modifies state.
+	MethodMutation
+)
+
+// String returns the name of the MethodKind.
+func (k MethodKind) String() string {
+	switch k {
+	case MethodQuery:
+		return "Query"
+	case MethodMutation:
+		return "Mutation"
+	}
+	return "Unclassified"
+}
+
+// ClassifyMethods calls classify for each Method in Methods and stores the returned value
+// in the Method's Kind.
+//
+// Classifying the *Instance returned by TypeInfoCache.StatType classifies the methods of every
+// *Instance subsequently returned by Stat for that type.  Classifying an *Instance returned by
+// Stat affects only that *Instance.
+func (m *Instance) ClassifyMethods(classify func(m Method) MethodKind) {
+	m.mutable()
+	for k := range m.Methods {
+		m.Methods[k].Kind = classify(m.Methods[k])
+	}
+}
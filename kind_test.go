@@ -0,0 +1,60 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+// byConvention classifies methods named Get* or Count* as queries.
+func byConvention(m call.Method) call.MethodKind {
+	if strings.HasPrefix(m.Name, "Get") || strings.HasPrefix(m.Name, "Count") {
+		return call.MethodQuery
+	}
+	return call.MethodMutation
+}
+
+func ExampleInstance_ClassifyMethods() {
+	instance := call.Stat(&examples.Counter{})
+	instance.ClassifyMethods(byConvention)
+	for _, m := range instance.Methods {
+		fmt.Println(m.Name, m.Kind)
+	}
+
+	// Output: Count Query
+	// Incr Mutation
+}
+
+func TestInstance_ClassifyMethods(t *testing.T) {
+	chk := assert.New(t)
+	//
+	cache := call.NewTypeInfoCache()
+	// Stat copies are classified independently.
+	instance := cache.Stat(&examples.Counter{})
+	instance.ClassifyMethods(byConvention)
+	m, err := instance.Methods.Named("Count")
+	chk.NoError(err)
+	chk.Equal(call.MethodQuery, m.Kind)
+	m, err = cache.Stat(&examples.Counter{}).Methods.Named("Count")
+	chk.NoError(err)
+	chk.Equal(call.MethodUnclassified, m.Kind)
+	// Classifying the template affects subsequent Stat copies.
+	cache.StatType(reflect.TypeOf(&examples.Counter{})).ClassifyMethods(byConvention)
+	m, err = cache.Stat(&examples.Counter{}).Methods.Named("Incr")
+	chk.NoError(err)
+	chk.Equal(call.MethodMutation, m.Kind)
+	// RebindPointer retains the Kind of existing methods.
+	instance = cache.Stat(examples.Counter{})
+	instance.ClassifyMethods(byConvention)
+	chk.NoError(instance.RebindPointer(&examples.Counter{}))
+	chk.Equal(call.MethodQuery, instance.Methods[0].Kind)
+	chk.Equal(call.MethodUnclassified, instance.Methods[1].Kind)
+	//
+	chk.Equal("Unclassified", call.MethodKind(99).String())
+}
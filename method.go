@@ -1,7 +1,11 @@
 package call
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"reflect"
+	"time"
 )
 
 // Methods is a slice of Method.
@@ -17,6 +21,59 @@ func (m Methods) Named(name string) (Method, error) {
 	return Method{}, ErrNotFound
 }
 
+// WireNamed returns the Method with the following wire name or ErrNotFound; see
+// TypeInfoCache.SetMethodNameTransform.
+func (m Methods) WireNamed(name string) (Method, error) {
+	for _, elem := range m {
+		if elem.WireName == name {
+			return elem, nil
+		}
+	}
+	return Method{}, ErrNotFound
+}
+
+// ReceiverIsZero returns true if the receiver of any of the methods is the zero value of its
+// type; see Instance.ReceiverIsZero.  Methods created by StatBoundMethod have no receiver and
+// are not considered.
+func (m Methods) ReceiverIsZero() bool {
+	for _, elem := range m {
+		if elem.instance != nil && elem.instance.ReceiverIsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchingFunc returns the methods for which match returns true in their original order.
+func (m Methods) MatchingFunc(match func(m Method) bool) Methods {
+	var rv Methods
+	for _, elem := range m {
+		if match(elem) {
+			rv = append(rv, elem)
+		}
+	}
+	return rv
+}
+
+// CallMatching calls each method for which match returns true in order with arguments from
+// Args().  It is intended for running hooks discovered on a type, such as every method of
+// the form func() error whose name begins with Close.
+//
+// Every matching method is called.  Errors returned by the methods are prefixed with the
+// method name and returned as Errors; nil is returned if no method returned an error.
+func (m Methods) CallMatching(match func(m Method) bool) error {
+	var errs Errors
+	for _, elem := range m.MatchingFunc(match) {
+		if result := elem.Call(elem.Args()); result.Error != nil {
+			errs = append(errs, fmt.Errorf("%v: %w", elem.Name, result.Error))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
 // Method contains information about a single method on a Go type.
 //
 // Each instance of Method has an internal *Instance pointer that ties it
@@ -26,16 +83,86 @@ type Method struct {
 	// Name is the method name.
 	Name string
 
-	// Method is the reflect.Method value.
+	// WireName is the method name as exposed to external callers such as an RPC protocol.
+	//
+	// WireName is the result of the TypeInfoCache's method name transform applied to Name
+	// or is equal to Name if the cache does not have a transform.
+	WireName string
+
+	// Kind is the classification of the method as set by Instance.ClassifyMethods.
+	Kind MethodKind
+
+	// Method is the reflect.Method value as returned by reflect.Type.Method().
+	//
+	// Method is provided for callers that wish to perform their own invocation; Method.Index
+	// is the index of the method within the receiver type's method set.  For an Instance
+	// created by Stat or StatType it is also the index of this Method within Instance.Methods;
+	// it is not for a Method created by StatMethod or StatBoundMethod.  Method.Func is the
+	// same reflect.Value as Func.Func unless the method is replaced with Instance.Override.
 	Method reflect.Method
 
 	// A Method is a superset of a Func.
+	//
+	// Func is built from Method.Func and describes the method as a function whose first
+	// argument is the receiver.
 	*Func
 
-	// The Instance containing the receiver we are tied to.
+	// The Instance containing the receiver we are tied to; nil for a Method created by
+	// StatBoundMethod.
 	instance *Instance
 }
 
+// newMethod creates a Method bound to instance from the reflect.Method.
+func newMethod(instance *Instance, method reflect.Method) Method {
+	rv := Method{
+		instance: instance,
+		Name:     method.Name,
+		WireName: method.Name,
+		Method:   method,
+		Func:     newFunc(method.Func, method.Func.Type()),
+	}
+	if config := instance.config; config != nil {
+		if config.transform != nil {
+			rv.WireName = config.transform(method.Name)
+		}
+		rv.Func.middleware = config.middlewareFor(instance.receiverType)
+	}
+	// InCreate[0] represents the receiver which we do not need to create.
+	rv.Func.InCreate = rv.Func.InCreate[1:]
+	return rv
+}
+
+// StatBoundMethod wraps a bound method value, such as p.Greet, as a Method with the given name;
+// it allows bound method values and the methods of statted instances to be kept in the same
+// registry.
+//
+// The receiver is captured by fn and the Method has no receiver argument; argument 0 is the
+// first argument of fn.  The Method is not tied to an Instance: Instance returns nil, Kind is
+// the zero MethodKind, and Method.Index is zero.
+//
+// StatBoundMethod panics if fn is not a function.
+func StatBoundMethod(fn interface{}, name string) *Method {
+	T, F := reflect.TypeOf(fn), reflect.ValueOf(fn)
+	if T == nil || T.Kind() != reflect.Func {
+		panic(fmt.Sprintf("call: StatBoundMethod expects a function; got %T", fn))
+	}
+	return &Method{
+		Name:     name,
+		WireName: name,
+		Method:   reflect.Method{Name: name, Type: T, Func: F},
+		Func:     newFunc(F, T),
+	}
+}
+
+// offset returns the index of the first argument after the receiver; it is zero for a Method
+// created by StatBoundMethod.
+func (m Method) offset() int {
+	if m.instance == nil {
+		return 0
+	}
+	return 1
+}
+
 // Args returns an *Args type where its Values and Pointers members are populated with
 // the necessary values to call the method via Method.Call().
 //
@@ -43,12 +170,215 @@ type Method struct {
 // the correct receiver and nil respectively.
 func (m Method) Args() *Args {
 	args := m.Func.Args()
-	args.Values[0], args.Pointers[0] = m.instance.receiverValue, nil
+	if m.instance != nil {
+		args.Values[0], args.Pointers[0] = m.instance.receiverValue, nil
+	}
 	return args
 }
 
+// ArgsValues is the same as Func.ArgsValues except the receiver is provided in the 0 index
+// of Values.
+func (m Method) ArgsValues() *Args {
+	args := m.Func.ArgsValues()
+	if m.instance != nil {
+		args.Values[0] = m.instance.receiverValue
+	}
+	return args
+}
+
+// ArgsIf is the same as Func.ArgsIf except the receiver is always provided in the 0 index
+// of Values; pred is not called for the receiver.
+func (m Method) ArgsIf(pred func(arg Arg) bool) *Args {
+	args := m.Func.ArgsIf(pred)
+	if m.instance != nil {
+		args.Values[0], args.Pointers[0] = m.instance.receiverValue, nil
+	}
+	return args
+}
+
+// ArgsFrom is the same as Func.ArgsFrom except the receiver is provided in the 0 index of
+// Values; the receiver is never replaced from the container.
+func (m Method) ArgsFrom(container map[reflect.Type]interface{}) (*Args, []Arg) {
+	return m.ArgsFromChain(container)
+}
+
+// ArgsFromChain is the same as Func.ArgsFromChain except the receiver is provided in the
+// 0 index of Values; the receiver is never replaced from the containers.
+func (m Method) ArgsFromChain(containers ...map[reflect.Type]interface{}) (*Args, []Arg) {
+	return m.Func.fromChain(m.Args(), containers)
+}
+
+// ArgsFromValues is the same as Func.ArgsFromValues except the receiver is provided in the 0
+// index of Values; the receiver is never replaced from vals.
+func (m Method) ArgsFromValues(vals map[reflect.Type]reflect.Value) (*Args, []Arg) {
+	return m.Func.fromValues(m.Args(), vals)
+}
+
+// CallJSONArray is the same as Func.CallJSONArray except the receiver is provided
+// automatically; the elements of params are decoded into the non-receiver arguments.
+func (m Method) CallJSONArray(params json.RawMessage) (Result, error) {
+	return m.Func.callJSON(m.Args(), params, decodeJSONArray)
+}
+
+// CallJSONObject is the same as Func.CallJSONObject except the receiver is provided
+// automatically; see SetArgNames.
+func (m Method) CallJSONObject(params json.RawMessage) (Result, error) {
+	return m.Func.callJSON(m.Args(), params, m.Func.decodeJSONObject)
+}
+
+// CallValues is the same as Func.CallValues except the receiver is provided automatically;
+// values[k] is the value of argument k+1.
+func (m Method) CallValues(values ...interface{}) (Result, error) {
+	return m.Func.callValues(m.Args(), m.offset(), values)
+}
+
+// CallValuesSkipInterfaces is the same as Func.CallValuesSkipInterfaces except the receiver is
+// provided automatically.
+func (m Method) CallValuesSkipInterfaces(values ...interface{}) (Result, error) {
+	return m.Func.callValuesSkipInterfaces(m.Args(), m.offset(), values)
+}
+
+// CallWith calls the method on receiver instead of the receiver of its Instance; args are
+// typically created by Args() and Values[0] is replaced.  Each call may have a different
+// receiver without rebinding the Instance, therefore CallWith is safe for goroutines sharing
+// one Instance, including a frozen Instance; see Instance.Freeze.  An initializer set with
+// Instance.SetInit runs on the receiver of the Instance, not on receiver.
+//
+// receiver is converted with the same rules as CallValues.  An error wrapping ErrIncompatible
+// is returned if receiver does not match the method's receiver type or the Method was created
+// by StatBoundMethod; the method is not called in that case.
+//
+// As with Call() the args are returned to the argument pool.
+func (m Method) CallWith(receiver interface{}, args *Args) (Result, error) {
+	if m.instance == nil {
+		putArgs(args)
+		return Result{}, fmt.Errorf("%w: %v has no receiver", ErrIncompatible, m.Pretty())
+	}
+	V, err := valueOf(receiver, m.InTypes[0])
+	if err != nil {
+		putArgs(args)
+		return Result{}, fmt.Errorf("receiver: %w", err)
+	}
+	m.Func.set(args, 0, V)
+	return m.Call(args), nil
+}
+
+// CallSpread is the same as Func.CallSpread except the method's receiver is provided
+// automatically; fixed contains only the non-receiver, non-variadic arguments.
+func (m Method) CallSpread(fixed []interface{}, variadic interface{}) (Result, error) {
+	values := make([]reflect.Value, m.offset())
+	if m.instance != nil {
+		values[0] = m.instance.receiverValue
+	}
+	return m.Func.callSpread(values, fixed, variadic)
+}
+
+// DecodeAndCall is the same as Func.DecodeAndCall except the receiver is provided
+// automatically.
+func (m Method) DecodeAndCall(data []byte, d time.Duration) (Result, error) {
+	return m.Func.decodeAndCall(m.Args(), data, d)
+}
+
+// NewCall is the same as Func.NewCall except the receiver is provided automatically; indexes
+// given to WithArg include the receiver at index 0.
+func (m Method) NewCall(opts ...CallOption) (*PreparedCall, error) {
+	return m.Func.newCall(m.Args(), opts)
+}
+
+// HTTPHandler is the same as Func.HTTPHandler except the method is invoked on its receiver.
+//
+// The receiver is shared by every request; it must be safe for concurrent use.
+func (m Method) HTTPHandler() http.Handler {
+	return m.Func.httpHandler(m.Args)
+}
+
+// ID returns a key for the method that combines its receiver type and name; it is stable
+// across process runs and suitable for keys in a registry.
+//
+// The ID has the same form as the runtime's name for the method:
+//	github.com/nofeaturesonlybugs/call/examples.Counter.Count
+//	github.com/nofeaturesonlybugs/call/examples.(*Counter).Incr
+//
+// Receiver types that are not named, or pointers to named types, are represented by their
+// reflect.Type.String().  The ID of a Method created by StatBoundMethod is its Name.
+func (m Method) ID() string {
+	if m.instance == nil {
+		return m.Name
+	}
+	T, ptr := m.instance.receiverType, false
+	if T.Kind() == reflect.Ptr && T.Name() == "" {
+		T, ptr = T.Elem(), true
+	}
+	if T.Name() == "" || T.PkgPath() == "" {
+		return m.instance.receiverType.String() + "." + m.Name
+	} else if ptr {
+		return T.PkgPath() + ".(*" + T.Name() + ")." + m.Name
+	}
+	return T.PkgPath() + "." + T.Name() + "." + m.Name
+}
+
+// Instance returns the *Instance the method is bound to or nil for a Method created by
+// StatBoundMethod.
+func (m Method) Instance() *Instance {
+	return m.instance
+}
+
+// SetArgNames is the same as Func.SetArgNames except names does not include the receiver;
+// names[k] is the name of argument k+1 and len(names) must be NumIn-1.  A Method created by
+// StatBoundMethod has no receiver and names[k] is the name of argument k.
+//
+// Argument names are stored on the Method's *Func and are shared by Method values that
+// share the *Func; each Instance returned by Stat has its own *Func for every Method.
+func (m Method) SetArgNames(names ...string) error {
+	if m.instance == nil {
+		return m.Func.SetArgNames(names...)
+	} else if len(names) != m.NumIn-1 {
+		return fmt.Errorf("%w: %v expects %v argument names; got %v", ErrIncompatible, m.Pretty(), m.NumIn-1, len(names))
+	}
+	return m.Func.SetArgNames(append([]string{""}, names...)...)
+}
+
+// SetArgName is the same as Func.SetArgName except index does not include the receiver; it
+// names argument index+1.  A Method created by StatBoundMethod has no receiver and index is
+// the argument index.
+//
+// An error wrapping ErrNotFound is returned if index is out of range.
+func (m Method) SetArgName(index int, name string) error {
+	if index < 0 {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, m.Pretty(), index)
+	}
+	return m.Func.SetArgName(index+m.offset(), name)
+}
+
+// SetReadOnly records whether the method does not modify its receiver or any state reachable
+// from it.  Go can not prove this so the package trusts the assertion; it encodes knowledge
+// of the method for code that dispatches calls concurrently.
+//
+// A read-only method may be called concurrently from goroutines that share one Instance, each
+// with its own *Args from Args(), provided other methods that modify the receiver are not
+// called at the same time.  Methods that are not read-only must be synchronized by the caller
+// or called on separate copies of the Instance; see Instance.Copy.  Alternatively any method,
+// read-only or not, may be called concurrently with CallWith, which gives each call its own
+// receiver; CallWith is also allowed on an Instance that has been frozen with Instance.Freeze.
+//
+// The flag is stored on the Method's *Func like argument names; see SetArgNames.
+func (m Method) SetReadOnly(on bool) {
+	m.Func.mutable()
+	m.Func.readOnly = on
+}
+
+// ReadOnly returns true if the method was marked read-only with SetReadOnly.
+func (m Method) ReadOnly() bool {
+	return m.Func.readOnly
+}
+
 // Pretty returns a string representing the method-name( args... ) return-value(s).
 func (m Method) Pretty() string {
 	// Get Pretty from Func but replace leading 4 (func) with our method name.
 	return m.Name + m.Func.Pretty()[4:]
 }
+
+// String returns Pretty(); it allows a Method to be used directly with fmt verbs such as %v.
+func (m Method) String() string {
+	return m.Pretty()
+}
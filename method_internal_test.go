@@ -185,3 +185,94 @@ func Benchmark_Method_Call_UsingPool(b *testing.B) {
 		pool.Put(args)
 	}
 }
+
+func TestMethod_Args_ReceiverSlot(t *testing.T) {
+	// The receiver is in neither InCreate nor InCache; therefore Args() does not allocate
+	// a value for it and a method whose only argument is the receiver creates nothing.
+	m, err := Stat(&examples.Counter{}).Methods.Named("Count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, arg := range m.AllArgs() {
+		if arg.N == 0 {
+			t.Fatalf("receiver in %v", arg)
+		}
+	}
+	if raceEnabled {
+		t.Skip("sync.Pool drops values under the race detector")
+	}
+	putArgs(m.Args()) // Prime the argument pool.
+	if allocs := testing.AllocsPerRun(100, func() {
+		putArgs(m.Args())
+	}); allocs != 0 {
+		t.Fatalf("expected 0 allocations; got %v", allocs)
+	}
+}
+
+func Benchmark_Method_Args_ReceiverOnly(b *testing.B) {
+	m, err := Stat(&examples.Counter{}).Methods.Named("Count")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for k := 0; k < b.N; k++ {
+		putArgs(m.Args())
+	}
+}
+
+func TestFunc_SetSingleThreaded(t *testing.T) {
+	instance := Stat(&examples.Counter{N: 1})
+	m, err := instance.Methods.Named("Count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetSingleThreaded(true)
+	a := m.Args()
+	if rv := m.Call(a).Values[0]; rv != 1 {
+		t.Fatalf("expected 1; got %v", rv)
+	}
+	if b := m.Args(); a != b {
+		t.Fatal("expected the same *Args")
+	} else if m.Call(b); b.Values[0].IsValid() {
+		t.Fatal("expected *Args to be cleared")
+	}
+	// Copies own their own *Args.
+	cp, err := instance.Copy().Methods.Named("Count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := cp.Args(); a == b {
+		t.Fatal("expected copies to have a different *Args")
+	}
+	//
+	m.SetSingleThreaded(false)
+	if b := m.Args(); a == b || b.owned {
+		t.Fatal("expected a pooled *Args")
+	}
+}
+
+func TestFunc_SetHot(t *testing.T) {
+	m, err := Stat(&examples.Counter{N: 1}).Methods.Named("Count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.SetHot(2)
+	args := m.Args()
+	if args.pool != m.hot {
+		t.Fatal("expected *Args from the private pool")
+	} else if cap(args.Values) != m.NumIn {
+		t.Fatalf("expected capacity %v; got %v", m.NumIn, cap(args.Values))
+	}
+	if rv := m.Call(args).Values[0]; rv != 1 {
+		t.Fatalf("expected 1; got %v", rv)
+	}
+	// Copies share the private pool.
+	cp, err := m.Instance().Copy().Methods.Named("Count")
+	if err != nil {
+		t.Fatal(err)
+	} else if args = cp.Args(); args.pool != m.hot {
+		t.Fatal("expected copies to share the private pool")
+	}
+	putArgs(args)
+}
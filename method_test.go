@@ -3,8 +3,13 @@ package call_test
 import (
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/nofeaturesonlybugs/call"
 	"github.com/nofeaturesonlybugs/call/examples"
 )
@@ -87,6 +92,23 @@ func Benchmark_Method_Call_Current(b *testing.B) {
 	}
 }
 
+func Benchmark_Method_Call_SingleThreaded(b *testing.B) {
+	var talk examples.HTTP
+	instance := call.Stat(talk)
+	m, err := instance.Methods.Named("Handler")
+	if err != nil {
+		b.Fatal(err)
+	}
+	m.SetSingleThreaded(true)
+	//
+	b.ResetTimer()
+	var args *call.Args
+	for k := 0; k < b.N; k++ {
+		args = m.Args()
+		m.Call(args)
+	}
+}
+
 func Benchmark_Method_Call_ManyArgs(b *testing.B) {
 	var many examples.ManyArgs
 	instance := call.Stat(many)
@@ -100,3 +122,359 @@ func Benchmark_Method_Call_ManyArgs(b *testing.B) {
 		m.Call(args)
 	}
 }
+
+func ExampleMethod_Instance() {
+	bob := &examples.Person{Name: "Bob", Age: 40}
+	sally := &examples.Person{Name: "Sally", Age: 30}
+
+	m, _ := call.Stat(bob).Methods.Named("Greet") // error ignored for brevity
+	fmt.Println(m.Call(m.Args()).Values[0])
+
+	// Given only the Method we can navigate back to its Instance and rebind it.
+	m.Instance().Rebind(sally)
+	fmt.Println(m.Call(m.Args()).Values[0])
+
+	// Output: Hello!  My name is Bob and I am 40 year(s) old.
+	// Hello!  My name is Sally and I am 30 year(s) old.
+}
+
+// joiner has a variadic method.
+type joiner struct {
+	sep string
+}
+
+func (j joiner) Join(prefix string, parts ...string) string {
+	return prefix + strings.Join(parts, j.sep)
+}
+
+func (j joiner) Pair(a, b string) string {
+	return a + j.sep + b
+}
+
+func ExampleMethod_CallSpread() {
+	m, _ := call.Stat(joiner{sep: "-"}).Methods.Named("Join") // error ignored for brevity
+	// The receiver is provided by the Method.
+	result, err := m.CallSpread([]interface{}{"joined:"}, []string{"a", "b", "c"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Values[0])
+
+	// Output: joined:a-b-c
+}
+
+func TestMethod_ReflectMethod(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var talk examples.Talker
+	instance := call.Stat(talk)
+	for k, m := range instance.Methods {
+		chk.Equal(k, m.Method.Index)
+		chk.Equal(m.Name, m.Method.Name)
+		chk.Equal(m.Method.Func.Pointer(), m.Func.Func.Pointer())
+		chk.Equal(m.Method.Type, m.Func.Func.Type())
+	}
+	// RebindPointer replaces both.
+	instance = call.Stat(examples.Counter{})
+	chk.NoError(instance.RebindPointer(&examples.Counter{}))
+	for _, m := range instance.Methods {
+		chk.Equal(m.Method.Func.Pointer(), m.Func.Func.Pointer())
+		chk.Equal(m.Method.Type, m.Func.Func.Type())
+	}
+}
+
+func TestMethod_CallValues(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Pair")
+	chk.NoError(err)
+	result, err := m.CallValues("a", "b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	// The receiver can not have a default.
+	chk.ErrorIs(m.SetDefault(0, joiner{sep: "+"}), call.ErrNotFound)
+	chk.NoError(m.SetDefault(1, "x"))
+	result, err = m.CallValues()
+	chk.NoError(err)
+	chk.Equal([]interface{}{"x-"}, result.Values)
+	_, err = m.CallValues("a", nil, "c")
+	chk.ErrorIs(err, call.ErrIncompatible)
+}
+
+func TestMethod_ID(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(examples.Counter{}).Methods.Named("Count")
+	chk.NoError(err)
+	chk.Equal("github.com/nofeaturesonlybugs/call/examples.Counter.Count", m.ID())
+	m, err = call.Stat(&examples.Counter{}).Methods.Named("Incr")
+	chk.NoError(err)
+	chk.Equal("github.com/nofeaturesonlybugs/call/examples.(*Counter).Incr", m.ID())
+	m, err = call.Stat(joiner{}).Methods.Named("Join")
+	chk.NoError(err)
+	chk.Equal("github.com/nofeaturesonlybugs/call_test.joiner.Join", m.ID())
+	// Types without a name.
+	m, err = call.Stat(struct{ joiner }{}).Methods.Named("Pair")
+	chk.NoError(err)
+	chk.Equal("struct { call_test.joiner }.Pair", m.ID())
+}
+
+func TestMethod_String(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(joiner{}).Methods.Named("Pair")
+	chk.NoError(err)
+	chk.Equal("Pair (call_test.joiner, string, string) string", fmt.Sprint(m))
+	chk.Equal(m.Pretty(), fmt.Sprintf("%v", m))
+	chk.Equal("func (call_test.joiner, string, string) string", fmt.Sprint(m.Func))
+}
+
+// resources has several Close hooks.
+type resources struct {
+	closed *[]string
+}
+
+func (r resources) CloseCache() error {
+	*r.closed = append(*r.closed, "cache")
+	return nil
+}
+
+func (r resources) CloseDB() error {
+	*r.closed = append(*r.closed, "db")
+	return fmt.Errorf("db busy")
+}
+
+func (r resources) CloseWith(reason string) error {
+	return fmt.Errorf("not a hook")
+}
+
+func (r resources) Open() error {
+	return fmt.Errorf("not a hook")
+}
+
+// isCloser matches methods named Close* of the form func() error.
+func isCloser(m call.Method) bool {
+	return strings.HasPrefix(m.Name, "Close") && m.NumIn == 1 && m.NumOut == 1 &&
+		m.OutTypes[0] == reflect.TypeOf((*error)(nil)).Elem()
+}
+
+func ExampleMethods_CallMatching() {
+	var closed []string
+	instance := call.Stat(resources{closed: &closed})
+	err := instance.Methods.CallMatching(isCloser)
+	fmt.Println(closed, err)
+
+	// Output: [cache db] CloseDB: db busy
+}
+
+func TestMethods_MatchingFunc(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var closed []string
+	instance := call.Stat(resources{closed: &closed})
+	matched := instance.Methods.MatchingFunc(isCloser)
+	if chk.Len(matched, 2) {
+		chk.Equal("CloseCache", matched[0].Name)
+		chk.Equal("CloseDB", matched[1].Name)
+	}
+	chk.Empty(instance.Methods.MatchingFunc(func(call.Method) bool { return false }))
+	chk.NoError(instance.Methods.CallMatching(func(m call.Method) bool { return m.Name == "CloseCache" }))
+}
+
+func Benchmark_Method_Call_Parallel(b *testing.B) {
+	for _, hot := range []bool{false, true} {
+		b.Run(fmt.Sprintf("hot=%v", hot), func(b *testing.B) {
+			var talk examples.HTTP
+			m, err := call.Stat(talk).Methods.Named("Handler")
+			if err != nil {
+				b.Fatal(err)
+			}
+			if hot {
+				m.SetHot(16)
+			}
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					m.Call(m.Args())
+				}
+			})
+		})
+	}
+}
+
+// pair is returned by joiner.Parts.
+type pair struct {
+	A, B string
+}
+
+func (j joiner) Parts(a, b string) pair {
+	return pair{A: a + j.sep, B: b}
+}
+
+func TestMethod_CallStruct(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var calls int
+	cache := call.NewTypeInfoCache()
+	cache.Use(func(next func(*call.Args) call.Result) func(*call.Args) call.Result {
+		return func(args *call.Args) call.Result {
+			calls++
+			return next(args)
+		}
+	})
+	m, err := cache.Stat(joiner{sep: "-"}).Methods.Named("Parts")
+	chk.NoError(err)
+	var p pair
+	chk.NoError(m.CallStruct(m.Args(), &p))
+	chk.Equal(pair{A: "-"}, p)
+	chk.Equal(1, calls)
+}
+
+func Benchmark_Method_Args_Goodbye(b *testing.B) {
+	var talk examples.Talker
+	m, err := call.Stat(talk).Methods.Named("Goodbye")
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("Args", func(b *testing.B) {
+		b.ReportAllocs()
+		for k := 0; k < b.N; k++ {
+			m.Call(m.Args())
+		}
+	})
+	b.Run("ArgsValues", func(b *testing.B) {
+		b.ReportAllocs()
+		for k := 0; k < b.N; k++ {
+			m.Call(m.ArgsValues())
+		}
+	})
+}
+
+func TestMethod_ArgsValues(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Pair")
+	chk.NoError(err)
+	chk.NoError(m.SetDefault(2, "b"))
+	args := m.ArgsValues()
+	chk.Len(args.Values, 3)
+	chk.Empty(args.Pointers)
+	chk.ErrorIs(args.SetText(1, "x"), call.ErrNotFound)
+	args.Values[1] = reflect.ValueOf("a")
+	chk.Equal([]interface{}{"a-b"}, m.Call(args).Values)
+	// Pointers are restored by Args.
+	args = m.Args()
+	chk.Len(args.Pointers, 3)
+	m.Call(args)
+}
+
+func (j joiner) Split(s string) (string, string, error) {
+	parts := strings.SplitN(s, j.sep, 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("no %q in %q", j.sep, s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func TestMethod_CallFanOut(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Split")
+	chk.NoError(err)
+	left, right := make(chan interface{}, 1), make(chan interface{}, 1)
+	args := m.Args()
+	chk.NoError(args.SetText(1, "a-b"))
+	chk.NoError(m.CallFanOut(args, left, right))
+	chk.Equal("a", <-left)
+	chk.Equal("b", <-right)
+	//
+	chk.Error(m.CallFanOut(m.Args(), left, right))
+	chk.Empty(left)
+	chk.ErrorIs(m.CallFanOut(m.Args(), left), call.ErrIncompatible)
+}
+
+func TestStatBoundMethod(t *testing.T) {
+	chk := assert.New(t)
+	//
+	p := examples.Person{Name: "Bob", Age: 40}
+	m := call.StatBoundMethod(p.Greet, "Greet")
+	chk.Equal("Greet", m.ID())
+	chk.Equal("Greet () string", m.Pretty())
+	chk.Nil(m.Instance())
+	result := m.Call(m.Args())
+	chk.Equal([]interface{}{p.Greet()}, result.Values)
+	//
+	j := joiner{sep: "-"}
+	registry := call.Methods{*m, *call.StatBoundMethod(j.Pair, "Pair")}
+	pairs, err := registry.Named("Pair")
+	chk.NoError(err)
+	result, err = pairs.CallValues("a", "b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	result, err = pairs.CallSpread(nil, nil)
+	chk.ErrorIs(err, call.ErrIncompatible)
+	//
+	chk.NoError(pairs.SetArgNames("a", "b"))
+	result, err = pairs.CallJSONObject([]byte(`{"a":"x","b":"y"}`))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"x-y"}, result.Values)
+	//
+	joins := call.StatBoundMethod(j.Join, "Join")
+	result, err = joins.CallSpread([]interface{}{">"}, []string{"a", "b"})
+	chk.NoError(err)
+	chk.Equal([]interface{}{">a-b"}, result.Values)
+	//
+	chk.Panics(func() { call.StatBoundMethod(42, "Answer") })
+}
+
+func TestMethod_SetReadOnly(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(joiner{sep: "-"})
+	m, err := instance.Methods.Named("Pair")
+	chk.NoError(err)
+	chk.False(m.ReadOnly())
+	cp := instance.Copy()
+	m.SetReadOnly(true)
+	// The flag is shared by Method values with the same *Func.
+	m, _ = instance.Methods.Named("Pair")
+	chk.True(m.ReadOnly())
+	m, _ = cp.Methods.Named("Pair")
+	chk.False(m.ReadOnly())
+	//
+	m, _ = instance.Methods.Named("Pair")
+	var wg sync.WaitGroup
+	for k := 0; k < 4; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := m.CallValues("a", "b")
+			chk.NoError(err)
+			chk.Equal([]interface{}{"a-b"}, result.Values)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestMethod_CallWith(t *testing.T) {
+	chk := assert.New(t)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Pair")
+	chk.NoError(err)
+	args := m.Args()
+	*args.Pointers[1].(*string), *args.Pointers[2].(*string) = "a", "b"
+	result, err := m.CallWith(&joiner{sep: "+"}, args)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a+b"}, result.Values)
+	// The Instance is unchanged.
+	result, err = m.CallValues("a", "b")
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, result.Values)
+	//
+	_, err = m.CallWith("joiner", m.Args())
+	chk.ErrorIs(err, call.ErrIncompatible)
+	bound := call.StatBoundMethod(joiner{}.Pair, "Pair")
+	_, err = bound.CallWith(joiner{}, bound.Args())
+	chk.ErrorIs(err, call.ErrIncompatible)
+}
@@ -0,0 +1,55 @@
+package call
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// argMetricsOn is non-zero when argument metrics are enabled; see SetArgMetrics.
+	argMetricsOn int32
+
+	// argMetrics maps a reflect.Type to a *uint64 count of arguments of that type created by
+	// Args().
+	argMetrics = &sync.Map{}
+)
+
+// SetArgMetrics sets whether Args() and ArgsIf() count the arguments they create by type for
+// every Func; see ArgMetrics.  Metrics are off by default since counting adds measurable
+// overhead to Args(); they are intended for profiling builds.
+//
+// Counts are kept when metrics are turned off and resume when they are turned on again.
+func SetArgMetrics(on bool) {
+	var v int32
+	if on {
+		v = 1
+	}
+	atomic.StoreInt32(&argMetricsOn, v)
+}
+
+// ArgMetrics returns the number of arguments of each type created by Args() and ArgsIf() while
+// metrics were enabled with SetArgMetrics; the total is the sum of the counts.  Arguments from
+// InCache are not created and are not counted.
+//
+// The returned map is a snapshot and may be modified by the caller.
+func ArgMetrics() map[reflect.Type]uint64 {
+	rv := map[reflect.Type]uint64{}
+	argMetrics.Range(func(key, value interface{}) bool {
+		rv[key.(reflect.Type)] = atomic.LoadUint64(value.(*uint64))
+		return true
+	})
+	return rv
+}
+
+// countArg increments the count of arguments of type T if metrics are enabled.
+func countArg(T reflect.Type) {
+	if atomic.LoadInt32(&argMetricsOn) == 0 {
+		return
+	}
+	count, ok := argMetrics.Load(T)
+	if !ok {
+		count, _ = argMetrics.LoadOrStore(T, new(uint64))
+	}
+	atomic.AddUint64(count.(*uint64), 1)
+}
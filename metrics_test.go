@@ -0,0 +1,44 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+func TestArgMetrics(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type metered struct{ N int }
+	T := reflect.TypeOf(metered{})
+	f := call.StatFunc(func(a, b metered, s fmt.Stringer) {})
+	f.Call(f.Args())
+	chk.Zero(call.ArgMetrics()[T])
+	//
+	call.SetArgMetrics(true)
+	defer call.SetArgMetrics(false)
+	var wg sync.WaitGroup
+	for k := 0; k < 4; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f.Call(f.Args())
+		}()
+	}
+	wg.Wait()
+	f.Call(f.ArgsIf(func(arg call.Arg) bool { return true }))
+	metrics := call.ArgMetrics()
+	chk.Equal(uint64(10), metrics[T])
+	// Interfaces are taken from InCache and are not created.
+	_, ok := metrics[reflect.TypeOf((*fmt.Stringer)(nil)).Elem()]
+	chk.False(ok)
+	//
+	call.SetArgMetrics(false)
+	f.Call(f.Args())
+	chk.Equal(uint64(10), call.ArgMetrics()[T])
+}
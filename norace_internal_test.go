@@ -0,0 +1,7 @@
+//go:build !race
+// +build !race
+
+package call
+
+// raceEnabled is true when tests are built with the race detector.
+const raceEnabled = false
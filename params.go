@@ -0,0 +1,83 @@
+package call
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Values of ParamDescriptor.Source.
+const (
+	// ParamBody is the source of fields with a json tag.
+	ParamBody = "body"
+	// ParamQuery is the source of fields with a form tag.
+	ParamQuery = "query"
+)
+
+// ParamDescriptor describes an externally visible parameter that is a field of a struct
+// argument; see Func.Parameters.
+type ParamDescriptor struct {
+	// Arg is the index of the struct argument containing the field.
+	Arg int
+	// Source is ParamBody or ParamQuery.
+	Source string
+	// Name is the name of the parameter from the field's tag.
+	Name string
+	// Type is the Go type of the field.
+	Type reflect.Type
+	// Required is true if the field's validate tag contains required.
+	Required bool
+}
+
+// Parameters flattens the fields of the struct arguments into a list of externally visible
+// parameters; it is intended for generating API documentation.
+//
+// Arguments of kind struct or pointer-to-struct are inspected; the receiver of a Method and
+// arguments removed by PruneIn are not.  Exported fields with a json
+// tag are ParamBody parameters and fields with a form tag but no json tag are ParamQuery
+// parameters; fields without either tag or tagged "-" are omitted.  Embedded structs without
+// tags are flattened recursively.  Parameters are returned in argument and field order.
+func (f *Func) Parameters() []ParamDescriptor {
+	var rv []ParamDescriptor
+	for _, arg := range f.AllArgs() {
+		T := arg.T
+		if T.Kind() == reflect.Ptr {
+			T = T.Elem()
+		}
+		if T.Kind() == reflect.Struct {
+			rv = appendParameters(rv, arg.N, T)
+		}
+	}
+	return rv
+}
+
+// appendParameters appends the parameters for the fields of the struct type T to rv.
+func appendParameters(rv []ParamDescriptor, n int, T reflect.Type) []ParamDescriptor {
+	tagName := func(sf reflect.StructField, tag string) string {
+		return strings.Split(sf.Tag.Get(tag), ",")[0]
+	}
+	for k, max := 0, T.NumField(); k < max; k++ {
+		sf := T.Field(k)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+		param := ParamDescriptor{Arg: n, Source: ParamBody, Name: tagName(sf, "json"), Type: sf.Type}
+		if param.Name == "" {
+			param.Source, param.Name = ParamQuery, tagName(sf, "form")
+		}
+		if param.Name == "-" {
+			continue
+		} else if param.Name == "" {
+			if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+				rv = appendParameters(rv, n, sf.Type)
+			}
+			continue
+		}
+		for _, rule := range strings.Split(sf.Tag.Get("validate"), ",") {
+			if rule == "required" {
+				param.Required = true
+			}
+		}
+		rv = append(rv, param)
+	}
+	return rv
+}
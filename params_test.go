@@ -0,0 +1,61 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_Parameters() {
+	m, _ := call.Stat(examples.HTTP{}).Methods.Named("Handler") // error ignored for brevity
+	for _, param := range m.Parameters() {
+		fmt.Println(param.Arg, param.Source, param.Name, param.Type)
+	}
+
+	// Output: 4 query username string
+	// 4 query password string
+}
+
+func TestFunc_Parameters(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Paging struct {
+		Page int `form:"page"`
+	}
+	type Create struct {
+		Paging
+		Name    string   `json:"name,omitempty" validate:"min=1,required"`
+		Tags    []string `json:"tags" form:"tag"`
+		Ignored string   `json:"-" form:"ignored"`
+		Plain   string
+	}
+	f := call.StatFunc(func(n int, create *Create, paging Paging) {})
+	chk.Equal([]call.ParamDescriptor{
+		{Arg: 1, Source: call.ParamQuery, Name: "page", Type: reflect.TypeOf(0)},
+		{Arg: 1, Source: call.ParamBody, Name: "name", Type: reflect.TypeOf(""), Required: true},
+		{Arg: 1, Source: call.ParamBody, Name: "tags", Type: reflect.TypeOf([]string(nil))},
+		{Arg: 2, Source: call.ParamQuery, Name: "page", Type: reflect.TypeOf(0)},
+	}, f.Parameters())
+	chk.Empty(call.StatFunc(func(int) {}).Parameters())
+	// The receiver of a method is not a parameter.
+	m, err := call.Stat(account{}).Methods.Named("Rename")
+	chk.NoError(err)
+	chk.Equal([]call.ParamDescriptor{
+		{Arg: 1, Source: call.ParamBody, Name: "name", Type: reflect.TypeOf("")},
+	}, m.Parameters())
+}
+
+// account has tagged fields that must not be reported by Parameters.
+type account struct {
+	Secret string `json:"secret"`
+}
+
+func (a account) Rename(form struct {
+	Name string `json:"name"`
+}) {
+}
@@ -0,0 +1,29 @@
+package call
+
+// ArgPlan describes the work performed by a call to Func.Args(); see Func.ArgPlan.
+type ArgPlan struct {
+	// Pooled is the number of Values and Pointers elements used from the pooled *Args.
+	Pooled int
+	// News is the number of reflect.New calls; one per argument in InCreate.
+	News int
+	// Bytes is the total size in bytes of the values allocated by reflect.New.
+	Bytes uintptr
+	// Shared is the number of values taken from InCache rather than allocated.
+	Shared int
+}
+
+// ArgPlan describes the allocation profile of Args() without calling it.
+//
+// ArgPlan is an analysis tool; functions whose plans have large News or Bytes counts are
+// good candidates for PruneIn.
+func (f *Func) ArgPlan() ArgPlan {
+	rv := ArgPlan{
+		Pooled: f.NumIn,
+		News:   len(f.InCreate),
+		Shared: len(f.InCache),
+	}
+	for _, arg := range f.InCreate {
+		rv.Bytes += arg.T.Size()
+	}
+	return rv
+}
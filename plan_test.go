@@ -0,0 +1,37 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_ArgPlan() {
+	fn := func(a, b int64, big [1024]byte, sess examples.Session) {}
+
+	f := call.StatFunc(fn)
+	fmt.Printf("%+v\n", f.ArgPlan())
+
+	f.PruneIn(reflect.TypeOf([1024]byte{}))
+	fmt.Printf("%+v\n", f.ArgPlan())
+
+	// Output: {Pooled:4 News:3 Bytes:1040 Shared:1}
+	// {Pooled:4 News:2 Bytes:16 Shared:1}
+}
+
+func TestFunc_ArgPlan(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var talk examples.HTTP
+	m, _ := call.Stat(talk).Methods.Named("Handler")
+	plan := m.ArgPlan()
+	chk.Equal(5, plan.Pooled)
+	chk.Equal(2, plan.News)
+	chk.Equal(2, plan.Shared)
+	chk.Equal(m.InTypes[2].Size()+m.InTypes[4].Size(), plan.Bytes)
+}
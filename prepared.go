@@ -0,0 +1,102 @@
+package call
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// CallOption configures the arguments of a PreparedCall; see Func.NewCall.  A CallOption
+// receives the Func and the *Args created by Args() and returns an error if it can not be
+// applied.  Applications may write their own options.
+type CallOption func(f *Func, args *Args) error
+
+// WithArg assigns v to the argument at index with the same rules as Func.Set.
+func WithArg(index int, v interface{}) CallOption {
+	return func(f *Func, args *Args) error {
+		return f.Set(args, index, v)
+	}
+}
+
+// WithProvider assigns v to every argument of type T; it is not an error if there are no such
+// arguments.  v must be assignable to T.
+func WithProvider(T reflect.Type, v interface{}) CallOption {
+	return func(f *Func, args *Args) error {
+		V, err := valueOf(v, T)
+		if err != nil {
+			return fmt.Errorf("provider %v: %w", T, err)
+		}
+		for n, in := range f.InTypes {
+			if in == T && n < len(args.Values) {
+				f.set(args, n, V)
+			}
+		}
+		return nil
+	}
+}
+
+// WithContext assigns ctx to every argument of type context.Context.
+func WithContext(ctx context.Context) CallOption {
+	return func(f *Func, args *Args) error {
+		f.setContext(args, ctx)
+		return nil
+	}
+}
+
+// PreparedCall is a function call whose arguments were assembled by Func.NewCall.
+type PreparedCall struct {
+	f    *Func
+	args *Args
+}
+
+// NewCall creates arguments with Args(), applies opts to them in order, and returns the call
+// ready to be invoked.  It composes the ways of wiring arguments into a single expression for
+// call sites assembled from scattered configuration:
+//
+//	prepared, err := f.NewCall(WithArg(1, req), WithProvider(loggerType, logger), WithContext(ctx))
+//	if err != nil {
+//		return err
+//	}
+//	result := prepared.Invoke()
+//
+// The first error returned by an option is returned and the arguments are released.
+//
+// The PreparedCall holds its arguments until Release; for a Func set with SetSingleThreaded
+// they are moved out of the *Args reused by Args(), which may therefore be called again
+// before the PreparedCall is invoked.
+func (f *Func) NewCall(opts ...CallOption) (*PreparedCall, error) {
+	return f.newCall(f.Args(), opts)
+}
+
+// newCall is the implementation of NewCall.
+func (f *Func) newCall(args *Args, opts []CallOption) (*PreparedCall, error) {
+	for _, opt := range opts {
+		if err := opt(f, args); err != nil {
+			putArgs(args)
+			return nil, err
+		}
+	}
+	if args.owned {
+		// The *Args of a single-threaded Func is reused by the next Args().
+		moved := getArgs(len(args.Values))
+		copy(moved.Values, args.Values)
+		copy(moved.Pointers, args.Pointers)
+		moved.pooled, args.pooled = append(moved.pooled, args.pooled...), args.pooled[:0]
+		putArgs(args)
+		args = moved
+	}
+	return &PreparedCall{f: f, args: args}, nil
+}
+
+// Invoke calls the function with a copy of the prepared arguments made with Args.Clone; the
+// PreparedCall may be invoked any number of times.
+func (p *PreparedCall) Invoke() Result {
+	return p.f.Call(p.args.Clone())
+}
+
+// Release returns the prepared arguments to the argument pool; the PreparedCall must not be
+// used afterwards.  Calling Release is optional.
+func (p *PreparedCall) Release() {
+	putArgs(p.args)
+	p.args = nil
+}
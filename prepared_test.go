@@ -0,0 +1,75 @@
+package call_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_NewCall() {
+	type ctxKey struct{}
+	handler := func(ctx context.Context, name string, sess examples.Session) string {
+		return fmt.Sprintf("%v %v %v", ctx.Value(ctxKey{}), name, sess != nil)
+	}
+
+	f := call.StatFunc(handler)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request-1")
+	prepared, err := f.NewCall(
+		call.WithContext(ctx),
+		call.WithArg(1, "Bob"),
+		call.WithProvider(reflect.TypeOf((*examples.Session)(nil)).Elem(), examples.MapSession{}),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(prepared.Invoke().Values...)
+
+	// Output: request-1 Bob true
+}
+
+func TestFunc_NewCall(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(a, b int, s string) string { return fmt.Sprint(a+b, s) })
+	prepared, err := f.NewCall(call.WithProvider(reflect.TypeOf(0), 2), call.WithArg(2, "x"))
+	chk.NoError(err)
+	// A PreparedCall may be invoked more than once.
+	chk.Equal([]interface{}{"4x"}, prepared.Invoke().Values)
+	chk.Equal([]interface{}{"4x"}, prepared.Invoke().Values)
+	prepared.Release()
+	//
+	_, err = f.NewCall(call.WithArg(3, 1))
+	chk.ErrorIs(err, call.ErrNotFound)
+	_, err = f.NewCall(call.WithProvider(reflect.TypeOf(0), "x"))
+	chk.ErrorIs(err, call.ErrIncompatible)
+	//
+	custom := func(f *call.Func, args *call.Args) error {
+		*args.Pointers[0].(*int) = 40
+		return nil
+	}
+	prepared, err = f.NewCall(custom, call.WithArg(1, 2))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"42"}, prepared.Invoke().Values)
+	//
+	m, err := call.Stat(joiner{sep: "-"}).Methods.Named("Pair")
+	chk.NoError(err)
+	prepared, err = m.NewCall(call.WithArg(1, "a"), call.WithArg(2, "b"))
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a-b"}, prepared.Invoke().Values)
+	// Prepared arguments are not the *Args reused by a single-threaded Func.
+	f.SetSingleThreaded(true)
+	prepared, err = f.NewCall(call.WithArg(0, 1), call.WithArg(1, 2), call.WithArg(2, "x"))
+	chk.NoError(err)
+	args := f.Args()
+	*args.Pointers[0].(*int) = 10
+	chk.Equal([]interface{}{"10"}, f.Call(args).Values)
+	chk.Equal([]interface{}{"3x"}, prepared.Invoke().Values)
+	prepared.Release()
+}
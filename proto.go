@@ -0,0 +1,62 @@
+package call
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ProtoUnmarshaler is implemented by protobuf messages that unmarshal wire data into
+// themselves, such as those generated by gogo/protobuf.  It allows UnmarshalProto to decode
+// protobuf arguments without this package depending on a protobuf runtime.
+type ProtoUnmarshaler interface {
+	Unmarshal(data []byte) error
+}
+
+// ProtoUnmarshal is used by UnmarshalProto for messages that do not implement
+// ProtoUnmarshaler.  It is nil by default; applications using google.golang.org/protobuf
+// can set it with an adapter:
+//
+//	call.ProtoUnmarshal = func(data []byte, msg interface{}) error {
+//		m, ok := msg.(proto.Message)
+//		if !ok {
+//			return fmt.Errorf("%T is not a proto.Message", msg)
+//		}
+//		return proto.Unmarshal(data, m)
+//	}
+//
+// ProtoUnmarshal should be set before any calls to UnmarshalProto and not modified afterwards.
+var ProtoUnmarshal func(data []byte, msg interface{}) error
+
+// UnmarshalProto decodes the protobuf wire data into the argument at index.
+//
+// Generated messages are used through pointers; if the argument is a pointer it is allocated
+// as needed and the pointer is the message, otherwise the argument's entry in Pointers is the
+// message.  If the message implements ProtoUnmarshaler its Unmarshal method is called;
+// otherwise ProtoUnmarshal is called.  An error wrapping ErrIncompatible is returned if neither
+// is available.
+//
+// The argument at index must have a non-nil entry in Pointers.
+func (args *Args) UnmarshalProto(index int, data []byte) error {
+	p, err := args.pointer(index)
+	if err != nil {
+		return err
+	}
+	msg := p
+	if V := reflect.ValueOf(p).Elem(); V.Kind() == reflect.Ptr {
+		if V.IsNil() {
+			V.Set(reflect.New(V.Type().Elem()))
+		}
+		msg = V.Interface()
+	}
+	if u, ok := msg.(ProtoUnmarshaler); ok {
+		err = u.Unmarshal(data)
+	} else if ProtoUnmarshal != nil {
+		err = ProtoUnmarshal(data, msg)
+	} else {
+		err = fmt.Errorf("%w: %T does not implement ProtoUnmarshaler and ProtoUnmarshal is not set", ErrIncompatible, msg)
+	}
+	if err != nil {
+		return fmt.Errorf("argument %v: %w", index, err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package call_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+// fakeMessage stands in for a generated protobuf message; its wire format is the raw bytes.
+type fakeMessage struct {
+	Data string
+}
+
+func (m *fakeMessage) Unmarshal(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty message")
+	}
+	m.Data = string(data)
+	return nil
+}
+
+// plainMessage does not implement call.ProtoUnmarshaler.
+type plainMessage struct {
+	Data string
+}
+
+func ExampleArgs_UnmarshalProto() {
+	handler := func(msg *fakeMessage) {
+		fmt.Println(msg.Data)
+	}
+
+	f := call.StatFunc(handler)
+	args := f.Args()
+	if err := args.UnmarshalProto(0, []byte("wire data")); err != nil {
+		fmt.Println(err)
+		return
+	}
+	f.Call(args)
+
+	// Output: wire data
+}
+
+func TestArgs_UnmarshalProto(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(fakeMessage, *plainMessage, int) {})
+	args := f.Args()
+	chk.NoError(args.UnmarshalProto(0, []byte("value")))
+	chk.Equal("value", args.Pointers[0].(*fakeMessage).Data)
+	chk.Error(args.UnmarshalProto(0, nil))
+	chk.ErrorIs(args.UnmarshalProto(1, []byte("x")), call.ErrIncompatible)
+	chk.ErrorIs(args.UnmarshalProto(3, []byte("x")), call.ErrNotFound)
+	//
+	call.ProtoUnmarshal = func(data []byte, msg interface{}) error {
+		m, ok := msg.(*plainMessage)
+		if !ok {
+			return fmt.Errorf("%T is not a message", msg)
+		}
+		m.Data = string(data)
+		return nil
+	}
+	defer func() { call.ProtoUnmarshal = nil }()
+	chk.NoError(args.UnmarshalProto(1, []byte("plain")))
+	chk.Equal("plain", (*args.Pointers[1].(**plainMessage)).Data)
+	chk.Error(args.UnmarshalProto(2, []byte("x")))
+}
@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package call
+
+// raceEnabled is true when tests are built with the race detector; sync.Pool randomly
+// drops values under the race detector so allocation counts are not reliable.
+const raceEnabled = true
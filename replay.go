@@ -0,0 +1,41 @@
+package call
+
+import (
+	"fmt"
+)
+
+// RecordedCall is a captured invocation of a method that can be replayed later with
+// Instance.Replay; see Method.Record.
+type RecordedCall struct {
+	// Method is the name of the method.
+	Method string
+
+	// Args is a copy of the arguments made with Args.Clone.  Args is never passed to Call
+	// and is not returned to the argument pool; each replay calls the method with a copy.
+	Args *Args
+}
+
+// Record captures the method name and a copy of args; it must be called before args is
+// passed to Call since Call returns args to the pool.
+func (m Method) Record(args *Args) RecordedCall {
+	return RecordedCall{Method: m.Name, Args: args.Clone()}
+}
+
+// Replay calls the method named by rc with a copy of the recorded arguments; the method is
+// invoked on the current receiver of the Instance rather than the receiver at the time of
+// recording, therefore a recorded call can be replayed after Rebind.
+//
+// An error wrapping ErrNotFound is returned if the Instance does not have the method and an
+// error wrapping ErrIncompatible is returned if the number of recorded arguments does not
+// match the method.  A RecordedCall can be replayed any number of times.
+func (m *Instance) Replay(rc RecordedCall) (Result, error) {
+	method, err := m.Methods.Named(rc.Method)
+	if err != nil {
+		return Result{}, fmt.Errorf("%w: %v", err, rc.Method)
+	} else if rc.Args == nil || len(rc.Args.Values) != method.NumIn {
+		return Result{}, fmt.Errorf("%w: %v expects %v arguments", ErrIncompatible, method.Pretty(), method.NumIn)
+	}
+	args := rc.Args.Clone()
+	args.Values[0], args.Pointers[0] = m.receiverValue, nil
+	return method.Call(args), nil
+}
@@ -0,0 +1,58 @@
+package call_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleInstance_Replay() {
+	bob := &examples.Person{Name: "Bob", Age: 40}
+	instance := call.Stat(bob)
+	m, _ := instance.Methods.Named("Greet") // error ignored for brevity
+	rc := m.Record(m.Args())
+
+	// Replays use the current receiver.
+	instance.Rebind(&examples.Person{Name: "Sally", Age: 30})
+	result, err := instance.Replay(rc)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(result.Values[0])
+
+	// Output: Hello!  My name is Sally and I am 30 year(s) old.
+}
+
+func TestInstance_Replay(t *testing.T) {
+	chk := assert.New(t)
+	//
+	instance := call.Stat(joiner{sep: "-"})
+	m, err := instance.Methods.Named("Pair")
+	chk.NoError(err)
+	args := m.Args()
+	chk.NoError(args.SetText(1, "a"))
+	chk.NoError(args.SetText(2, "b"))
+	rc := m.Record(args)
+	chk.Equal([]interface{}{"a-b"}, m.Call(args).Values)
+	// The recording is not affected by Call and can be replayed repeatedly.
+	for k := 0; k < 2; k++ {
+		result, err := instance.Replay(rc)
+		chk.NoError(err)
+		chk.Equal([]interface{}{"a-b"}, result.Values)
+	}
+	instance.Rebind(joiner{sep: "+"})
+	result, err := instance.Replay(rc)
+	chk.NoError(err)
+	chk.Equal([]interface{}{"a+b"}, result.Values)
+	//
+	_, err = instance.Replay(call.RecordedCall{Method: "Missing", Args: rc.Args})
+	chk.ErrorIs(err, call.ErrNotFound)
+	_, err = instance.Replay(call.RecordedCall{Method: "Pair", Args: call.NewArgs(reflect.ValueOf(joiner{}))})
+	chk.ErrorIs(err, call.ErrIncompatible)
+}
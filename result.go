@@ -1,5 +1,11 @@
 package call
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
 // Result is the result of invoking a function or method.
 type Result struct {
 	// If the function returns an error then Error is set to the returned error.
@@ -12,4 +18,102 @@ type Result struct {
 
 	// Values holds the returned values.
 	Values []interface{}
+
+	// ErrorSlots has the same length as Values and ErrorSlots[k] is true when the declared
+	// type of return value k implements error.  Consumers that enumerate the data returned
+	// by a function can use ErrorSlots to skip error returns even when they are nil.
+	//
+	// ErrorSlots is shared by every Result of the same function and must not be modified.
+	ErrorSlots []bool
+
+	// Code is the code registered with Func.MapError for the first sentinel that Error
+	// matches, or zero.
+	Code int
+
+	// Duration is the time spent executing the function when timing is enabled with
+	// Func.TimeCalls; otherwise it is zero.
+	Duration time.Duration
+
+	// panicked is true if Guard recovered a panic; recovered is the recovered value.
+	panicked  bool
+	recovered interface{}
+}
+
+// Panicked returns true if the Result was returned by Guard or SafeCall after recovering a
+// panic; see Repanic.
+func (r Result) Panicked() bool {
+	return r.panicked
+}
+
+// Repanic re-raises the panic recovered by Guard or SafeCall with the original value; it does
+// nothing if no panic was recovered.  It allows cleanup or logging at the call boundary
+// followed by propagation to an outer recover:
+//
+//	result, err := f.SafeCall(args)
+//	if err != nil {
+//		log.Println(err)
+//		result.Repanic()
+//	}
+func (r Result) Repanic() {
+	if r.panicked {
+		panic(r.recovered)
+	}
+}
+
+// IsContextError returns true if Error is or wraps context.Canceled or
+// context.DeadlineExceeded; gateways commonly map such errors to specific status codes.
+func (r Result) IsContextError() bool {
+	return errors.Is(r.Error, context.Canceled) || errors.Is(r.Error, context.DeadlineExceeded)
+}
+
+// Payload returns Values without the return values whose declared type implements error;
+// the error slots are identified by ErrorSlots so nil errors are removed as well, wherever
+// they appear in the return list.
+//
+// If ErrorSlots does not match Values, such as for a Result not created by this package,
+// elements that are non-nil errors are removed instead.
+func (r Result) Payload() []interface{} {
+	rv := make([]interface{}, 0, len(r.Values))
+	for k, v := range r.Values {
+		if len(r.ErrorSlots) == len(r.Values) {
+			if r.ErrorSlots[k] {
+				continue
+			}
+		} else if _, ok := v.(error); ok {
+			continue
+		}
+		rv = append(rv, v)
+	}
+	return rv
+}
+
+// Unwrap returns the first return value whose declared type does not implement error along
+// with Error; it collapses the common (T, error) return to v, err := result.Unwrap().
+//
+// A function without such a return value, including one that returns nothing or only an
+// error, gives a nil value.  Non-error return values are identified as with Payload.
+func (r Result) Unwrap() (interface{}, error) {
+	for k, v := range r.Values {
+		if len(r.ErrorSlots) == len(r.Values) {
+			if r.ErrorSlots[k] {
+				continue
+			}
+		} else if _, ok := v.(error); ok {
+			continue
+		}
+		return v, r.Error
+	}
+	return nil, r.Error
+}
+
+// Reset prepares the Result for reuse with CallInto.  Error and ErrorSlots are set to nil, Code
+// is set to zero, and Values is truncated to length zero while keeping its capacity.
+//
+// The elements of Values from prior calls are cleared so the Result does not keep them alive.
+func (r *Result) Reset() {
+	for k := range r.Values {
+		r.Values[k] = nil
+	}
+	r.Error, r.ErrorSlots, r.Values, r.Code, r.Duration = nil, nil, r.Values[:0], 0, 0
+	r.panicked, r.recovered = false, nil
 }
@@ -0,0 +1,225 @@
+package call_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleResult_errorSlots() {
+	fn := func() (*int, error, string) {
+		return nil, nil, "data"
+	}
+
+	f := call.StatFunc(fn)
+	result := f.Call(f.Args())
+	// The nil *int and the nil error are indistinguishable in Values but ErrorSlots
+	// identifies which is the error.
+	for k, v := range result.Values {
+		if result.ErrorSlots[k] {
+			continue
+		}
+		fmt.Println(k, v)
+	}
+
+	// Output: 0 <nil>
+	// 2 data
+}
+
+func TestResult_ErrorSlots(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func() (error, int, *call.PanicError) { return nil, 0, nil })
+	result := f.Call(f.Args())
+	chk.Equal([]bool{true, false, true}, result.ErrorSlots)
+	chk.Len(result.Values, 3)
+	//
+	f = call.StatFunc(func() {})
+	result = f.Call(f.Args())
+	chk.Empty(result.ErrorSlots)
+	chk.Empty(result.Values)
+}
+
+func ExampleResult_Reset() {
+	fn := func(n int) (int, error) {
+		if n%2 == 1 {
+			return 0, fmt.Errorf("odd %v", n)
+		}
+		return n, nil
+	}
+
+	f := call.StatFunc(fn)
+	var result call.Result
+	for n := 0; n < 3; n++ {
+		args := f.Args()
+		*args.Pointers[0].(*int) = n
+		// CallInto resets result before the call.
+		f.CallInto(args, &result)
+		fmt.Println(result.Values[0], result.Error)
+	}
+
+	// Output: 0 <nil>
+	// 0 odd 1
+	// 2 <nil>
+}
+
+func TestResult_Reset(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func() (string, error) { return "data", fmt.Errorf("oops") })
+	result := f.Call(f.Args())
+	values := result.Values
+	result.Reset()
+	chk.NoError(result.Error)
+	chk.Nil(result.ErrorSlots)
+	chk.Len(result.Values, 0)
+	chk.Equal(cap(values), cap(result.Values))
+	// Retained elements are cleared.
+	chk.Equal([]interface{}{nil, nil}, values)
+	//
+	var zero call.Result
+	zero.Reset()
+	chk.Len(zero.Values, 0)
+}
+
+func TestFunc_CallInto(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(s string) (string, error) { return s + "!", nil })
+	result := call.Result{Error: fmt.Errorf("stale"), Values: make([]interface{}, 0, 4)}
+	args := f.Args()
+	*args.Pointers[0].(*string) = "hi"
+	f.CallInto(args, &result)
+	chk.NoError(result.Error)
+	chk.Equal([]interface{}{"hi!", nil}, result.Values)
+	chk.Equal([]bool{false, true}, result.ErrorSlots)
+	chk.Equal(4, cap(result.Values))
+	// With middleware.
+	var calls int
+	cache := call.NewTypeInfoCache()
+	cache.Use(func(next func(*call.Args) call.Result) func(*call.Args) call.Result {
+		return func(args *call.Args) call.Result {
+			calls++
+			return next(args)
+		}
+	})
+	method, err := cache.Stat(&examples.Counter{N: 3}).Methods.Named("Count")
+	chk.NoError(err)
+	method.CallInto(method.Args(), &result)
+	chk.Equal(1, calls)
+	chk.Equal([]interface{}{3}, result.Values)
+	chk.Equal(4, cap(result.Values))
+}
+
+func ExampleResult_Payload() {
+	fn := func() (error, string, int) {
+		return nil, "data", 42
+	}
+
+	f := call.StatFunc(fn)
+	result := f.Call(f.Args())
+	fmt.Println(result.Payload()...)
+
+	// Output: data 42
+}
+
+func TestResult_Payload(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func() (*int, error) { return nil, fmt.Errorf("oops") })
+	result := f.Call(f.Args())
+	chk.Equal([]interface{}{(*int)(nil)}, result.Payload())
+	//
+	result = call.Result{Values: []interface{}{1, fmt.Errorf("oops"), nil}}
+	chk.Equal([]interface{}{1, nil}, result.Payload())
+	chk.Empty(call.Result{}.Payload())
+}
+
+func TestResult_IsContextError(t *testing.T) {
+	chk := assert.New(t)
+	//
+	chk.False(call.Result{}.IsContextError())
+	chk.False(call.Result{Error: fmt.Errorf("oops")}.IsContextError())
+	chk.True(call.Result{Error: context.Canceled}.IsContextError())
+	chk.True(call.Result{Error: fmt.Errorf("wrapped: %w", context.DeadlineExceeded)}.IsContextError())
+}
+
+func TestFunc_MapError(t *testing.T) {
+	chk := assert.New(t)
+	//
+	errNotFound := fmt.Errorf("not found")
+	errMissingUser := fmt.Errorf("user: %w", errNotFound)
+	errDenied := fmt.Errorf("denied")
+	f := call.StatFunc(func(err error) error { return err })
+	f.MapError(errMissingUser, 410)
+	f.MapError(errNotFound, 404)
+	f.MapError(errDenied, 403)
+	cp := *f
+	cp.MapError(context.Canceled, 499)
+	for _, test := range []struct {
+		Err  error
+		Code int
+	}{
+		{nil, 0},
+		{errMissingUser, 410},
+		{fmt.Errorf("wrapped: %w", errNotFound), 404},
+		{errDenied, 403},
+		{context.Canceled, 0},
+		{fmt.Errorf("other"), 0},
+	} {
+		result, err := f.CallValues(test.Err)
+		chk.NoError(err)
+		chk.Equal(test.Code, result.Code, "%v", test.Err)
+	}
+	result, err := cp.CallValues(context.Canceled)
+	chk.NoError(err)
+	chk.Equal(499, result.Code)
+	// CallInto sets and Reset clears the code.
+	args := f.Args()
+	args.Values[0] = reflect.ValueOf(errDenied)
+	f.CallInto(args, &result)
+	chk.Equal(403, result.Code)
+	result.Reset()
+	chk.Zero(result.Code)
+}
+
+func TestResult_Unwrap(t *testing.T) {
+	chk := assert.New(t)
+	//
+	errFailed := fmt.Errorf("failed")
+	f := call.StatFunc(func(fail bool) (error, string, int, error) {
+		if fail {
+			return nil, "partial", 0, errFailed
+		}
+		return nil, "ok", 1, nil
+	})
+	result, err := f.CallValues(false)
+	chk.NoError(err)
+	v, err := result.Unwrap()
+	chk.NoError(err)
+	chk.Equal("ok", v)
+	result, _ = f.CallValues(true)
+	v, err = result.Unwrap()
+	chk.ErrorIs(err, errFailed)
+	chk.Equal("partial", v)
+	//
+	void := call.StatFunc(func() {})
+	v, err = void.Call(void.Args()).Unwrap()
+	chk.Nil(v)
+	chk.NoError(err)
+	errOnly := call.StatFunc(func() error { return errFailed })
+	result = errOnly.Call(errOnly.Args())
+	v, err = result.Unwrap()
+	chk.Nil(v)
+	chk.ErrorIs(err, errFailed)
+	// Without ErrorSlots non-nil errors are skipped.
+	v, err = call.Result{Values: []interface{}{errFailed, 42}}.Unwrap()
+	chk.Equal(42, v)
+	chk.NoError(err)
+}
@@ -0,0 +1,45 @@
+package call
+
+import (
+	"time"
+)
+
+// RetryPolicy configures CallRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the function is called; values less than
+	// one are treated as one.
+	MaxAttempts int
+
+	// Backoff returns the time to wait after the failed attempt numbered attempt, beginning
+	// at 1, and before the next attempt.  If Backoff is nil there is no wait.
+	Backoff func(attempt int) time.Duration
+
+	// IsRetryable returns true if err is transient and the call should be attempted again.
+	// If IsRetryable is nil no errors are retried.
+	IsRetryable func(err error) bool
+}
+
+// CallRetry invokes the function with args and calls it again while the returned Result.Error
+// is retryable according to policy.  The Result of the final attempt is returned.
+//
+// Args() is not called by CallRetry; it is called only once by the caller to create args.
+// Every attempt except the last possible one is made with args.Clone() so providers and
+// decoders that populated args are not repeated.  Values without a pointer, such as those
+// from InCache or a Method's receiver, are shared by all attempts.
+//
+// As with Call() the args are returned to the argument pool.
+func (f *Func) CallRetry(args *Args, policy RetryPolicy) Result {
+	for attempt := 1; ; attempt++ {
+		if attempt >= policy.MaxAttempts {
+			return f.Call(args)
+		}
+		result := f.Call(args.Clone())
+		if result.Error == nil || policy.IsRetryable == nil || !policy.IsRetryable(result.Error) {
+			putArgs(args)
+			return result
+		}
+		if policy.Backoff != nil {
+			time.Sleep(policy.Backoff(attempt))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package call_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+)
+
+// errTransient is a retryable error.
+var errTransient = errors.New("transient")
+
+func ExampleFunc_CallRetry() {
+	attempts := 0
+	fetch := func(id int) (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", errTransient
+		}
+		return fmt.Sprintf("item %v", id), nil
+	}
+
+	f := call.StatFunc(fetch)
+	args := f.Args()
+	*args.Pointers[0].(*int) = 42
+	result := f.CallRetry(args, call.RetryPolicy{
+		MaxAttempts: 5,
+		Backoff: func(attempt int) time.Duration {
+			return time.Duration(attempt) * time.Millisecond
+		},
+		IsRetryable: func(err error) bool {
+			return errors.Is(err, errTransient)
+		},
+	})
+	fmt.Println(result.Values[0], result.Error, attempts)
+
+	// Output: item 42 <nil> 3
+}
+
+func TestFunc_CallRetry(t *testing.T) {
+	chk := assert.New(t)
+	//
+	var seen []int
+	errFatal := errors.New("fatal")
+	f := call.StatFunc(func(n *int, fatal bool) error {
+		seen = append(seen, *n)
+		// Clones share the *int; later attempts see this mutation.
+		*n = -1
+		if fatal {
+			return errFatal
+		}
+		return errTransient
+	})
+	isRetryable := func(err error) bool { return errors.Is(err, errTransient) }
+	//
+	args := f.Args()
+	*args.Pointers[0].(**int) = new(int)
+	**args.Pointers[0].(**int) = 5
+	result := f.CallRetry(args, call.RetryPolicy{MaxAttempts: 3, IsRetryable: isRetryable})
+	chk.ErrorIs(result.Error, errTransient)
+	// The *int is shared by the clones.
+	chk.Equal([]int{5, -1, -1}, seen)
+	//
+	seen = nil
+	args = f.Args()
+	*args.Pointers[0].(**int) = new(int)
+	*args.Pointers[1].(*bool) = true
+	result = f.CallRetry(args, call.RetryPolicy{MaxAttempts: 3, IsRetryable: isRetryable})
+	chk.ErrorIs(result.Error, errFatal)
+	chk.Len(seen, 1)
+	// Zero value policy makes a single attempt.
+	seen = nil
+	args = f.Args()
+	*args.Pointers[0].(**int) = new(int)
+	f.CallRetry(args, call.RetryPolicy{})
+	chk.Len(seen, 1)
+}
+
+func TestArgs_Clone(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(s string, err error) {})
+	args := f.Args()
+	*args.Pointers[0].(*string) = "a"
+	cp := args.Clone()
+	*args.Pointers[0].(*string) = "b"
+	chk.Equal("a", *cp.Pointers[0].(*string))
+	chk.Equal("a", cp.Values[0].Interface())
+	chk.Nil(cp.Pointers[1])
+	chk.True(cp.Values[1] == args.Values[1])
+}
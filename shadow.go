@@ -0,0 +1,88 @@
+package call
+
+import (
+	"reflect"
+	"sort"
+)
+
+// shadowedMethods returns the sorted names of methods in the method sets of the embedded
+// fields of T that are shadowed by a method declared on T or that collide with a method of
+// the same name from another embedded field.  T may be a struct or pointer-to-struct;
+// nil is returned for any other type.
+func shadowedMethods(T reflect.Type) []string {
+	if T.Kind() == reflect.Ptr {
+		T = T.Elem()
+	}
+	if T.Kind() != reflect.Struct {
+		return nil
+	}
+	counts := map[string]int{}
+	// from maps a method name to the type of the embedded field providing it.
+	from := map[string]reflect.Type{}
+	for k, max := 0, T.NumField(); k < max; k++ {
+		field := T.Field(k)
+		if !field.Anonymous {
+			continue
+		}
+		E := field.Type
+		if E.Kind() != reflect.Ptr && E.Kind() != reflect.Interface {
+			E = reflect.PtrTo(E)
+		}
+		for n, num := 0, E.NumMethod(); n < num; n++ {
+			counts[E.Method(n).Name]++
+			from[E.Method(n).Name] = field.Type
+		}
+	}
+	var rv []string
+	for name, count := range counts {
+		if count > 1 || declaresMethod(T, from[name], name) {
+			rv = append(rv, name)
+		}
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// declaresMethod returns true if the method name promoted from the embedded field of type E
+// is shadowed by a method declared on T or *T.
+//
+// reflect does not report where a method is declared; instead the method sets of T and *T
+// are compared with the method sets that promotion from E produces.  A declared method is
+// detected if its signature differs from the promoted method or if its receiver changes
+// whether the method is in the method set of T.  A method declared with the same signature
+// and the same kind of receiver as the method it shadows can not be told apart from the
+// promoted method and is not detected.
+func declaresMethod(T, E reflect.Type, name string) bool {
+	declared, ok := reflect.PtrTo(T).MethodByName(name)
+	if !ok {
+		return false
+	}
+	_, inValue := T.MethodByName(name)
+	promoted, promotedToValue := E.MethodByName(name)
+	if E.Kind() == reflect.Ptr || E.Kind() == reflect.Interface {
+		promotedToValue = true
+	} else if !promotedToValue {
+		promoted, _ = reflect.PtrTo(E).MethodByName(name)
+	}
+	if inValue != promotedToValue {
+		return true
+	}
+	return signature(declared.Type, true) != signature(promoted.Type, E.Kind() != reflect.Interface)
+}
+
+// signature returns the function type of a method without its receiver; receiver is false
+// for the methods of interface types, which do not have one.
+func signature(T reflect.Type, receiver bool) reflect.Type {
+	if !receiver {
+		return T
+	}
+	in := make([]reflect.Type, 0, T.NumIn()-1)
+	for k := 1; k < T.NumIn(); k++ {
+		in = append(in, T.In(k))
+	}
+	out := make([]reflect.Type, 0, T.NumOut())
+	for k := 0; k < T.NumOut(); k++ {
+		out = append(out, T.Out(k))
+	}
+	return reflect.FuncOf(in, out, T.IsVariadic())
+}
@@ -0,0 +1,69 @@
+package call_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+type shadowInner struct{}
+
+func (shadowInner) Get() string   { return "inner" }
+func (shadowInner) Keep() string  { return "inner" }
+func (*shadowInner) Write() error { return nil }
+
+type shadowOther struct{}
+
+func (shadowOther) Both() {}
+
+type shadowAnother struct{}
+
+func (*shadowAnother) Both() {}
+
+type shadowOuter struct {
+	shadowInner
+	*shadowOther
+	shadowAnother
+	io.Closer
+}
+
+// Get has a different signature and Write and Close a different kind of receiver than the
+// methods they shadow; see ShadowedMethods.
+func (shadowOuter) Get() interface{} { return "outer" }
+func (shadowOuter) Write() error     { return nil }
+func (*shadowOuter) Close() error    { return nil }
+func (shadowOuter) Declared() bool   { return true }
+
+type shadowSame struct {
+	shadowInner
+}
+
+func (shadowSame) Keep() string { return "same" }
+
+func ExampleInstance_ShadowedMethods() {
+	instance := call.Stat(shadowOuter{})
+	fmt.Println(instance.ShadowedMethods())
+
+	// Output: [Both Close Get Write]
+}
+
+func TestInstance_ShadowedMethods(t *testing.T) {
+	chk := assert.New(t)
+	//
+	chk.Equal([]string{"Both", "Close", "Get", "Write"}, call.Stat(&shadowOuter{}).ShadowedMethods())
+	chk.Empty(call.Stat(examples.Talker{}).ShadowedMethods())
+	chk.Empty(call.Stat(42).ShadowedMethods())
+	// Methods with the same signature and kind of receiver are indistinguishable.
+	chk.Empty(call.Stat(shadowSame{}).ShadowedMethods())
+	//
+	// The returned slice is a copy.
+	instance := call.Stat(shadowOuter{})
+	shadowed := instance.ShadowedMethods()
+	shadowed[0] = "modified"
+	chk.Equal("Both", instance.ShadowedMethods()[0])
+}
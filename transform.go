@@ -0,0 +1,45 @@
+package call
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// argTransform is a function registered for the argument at index N; see Func.AddArgTransform.
+type argTransform struct {
+	N  int
+	fn func(v reflect.Value)
+}
+
+// AddArgTransform registers fn to normalize the argument at index before the function is called,
+// for example to trim or lowercase strings or to set derived fields.  Transforms are run by
+// Args.Apply in the order they were registered.
+//
+// v is the addressable value at Pointers[index] and fn modifies it in place; arguments without
+// a pointer, such as interfaces from InCache or values assigned with Set, are not transformed.
+//
+// An error wrapping ErrNotFound is returned if index is out of range.
+func (f *Func) AddArgTransform(index int, fn func(v reflect.Value)) error {
+	f.mutable()
+	if index < 0 || index >= f.NumIn {
+		return fmt.Errorf("%w: %v has no argument %v", ErrNotFound, f.Pretty(), index)
+	}
+	f.transforms = append(cloned(f.transforms).([]argTransform), argTransform{N: index, fn: fn})
+	return nil
+}
+
+// Apply runs the argument transforms registered on f with Func.AddArgTransform; it is called
+// after the arguments are populated and before Call.
+//
+//	args := f.Args()
+//	json.Unmarshal(data, args.Pointers[1])
+//	args.Apply(f)
+//	result := f.Call(args)
+func (args *Args) Apply(f *Func) {
+	for _, t := range f.transforms {
+		if t.N >= len(args.Pointers) || args.Pointers[t.N] == nil {
+			continue
+		}
+		t.fn(reflect.ValueOf(args.Pointers[t.N]).Elem())
+	}
+}
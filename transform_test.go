@@ -0,0 +1,46 @@
+package call_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+// signup is normalized by argument transforms.
+type signup struct {
+	Email string
+	Name  string
+}
+
+func TestArgs_Apply(t *testing.T) {
+	chk := assert.New(t)
+	//
+	f := call.StatFunc(func(s signup, tag string, sess examples.Session) string {
+		return s.Email + "," + s.Name + "," + tag
+	})
+	chk.NoError(f.AddArgTransform(0, func(v reflect.Value) {
+		v.FieldByName("Email").SetString(strings.ToLower(v.FieldByName("Email").String()))
+	}))
+	chk.NoError(f.AddArgTransform(0, func(v reflect.Value) {
+		v.FieldByName("Name").SetString(strings.TrimSpace(v.FieldByName("Name").String()))
+	}))
+	// Transforms run in registration order.
+	chk.NoError(f.AddArgTransform(1, func(v reflect.Value) { v.SetString(v.String() + "a") }))
+	chk.NoError(f.AddArgTransform(1, func(v reflect.Value) { v.SetString(v.String() + "b") }))
+	// The interface argument has no pointer and is not transformed.
+	chk.NoError(f.AddArgTransform(2, func(v reflect.Value) { t.Fatal("unexpected transform") }))
+	//
+	args := f.Args()
+	*args.Pointers[0].(*signup) = signup{Email: "Bob@Example.COM", Name: " Bob "}
+	args.Apply(f)
+	result := f.Call(args)
+	chk.Equal([]interface{}{"bob@example.com,Bob,ab"}, result.Values)
+	//
+	chk.ErrorIs(f.AddArgTransform(3, func(v reflect.Value) {}), call.ErrNotFound)
+	chk.ErrorIs(f.AddArgTransform(-1, func(v reflect.Value) {}), call.ErrNotFound)
+}
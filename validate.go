@@ -0,0 +1,96 @@
+package call
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// structArgs calls fn with the index and pointer of each struct argument that has an entry
+// in args.Pointers; iteration stops at the first error which is then returned.
+func (f *Func) structArgs(args *Args, fn func(n int, pointer interface{}) error) error {
+	for n, kind := range f.InKinds {
+		if kind != reflect.Struct || n >= len(args.Pointers) || args.Pointers[n] == nil {
+			continue
+		}
+		if err := fn(n, args.Pointers[n]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StructArgPointers returns the Pointers entries of the struct arguments in args keyed by
+// argument index; callers can decode into each entry without checking argument kinds.
+//
+// Only arguments of kind struct are included; pointer-to-struct arguments are not.  Arguments
+// without an entry in args.Pointers, such as pruned arguments, are skipped.
+func (f *Func) StructArgPointers(args *Args) map[int]interface{} {
+	rv := map[int]interface{}{}
+	_ = f.structArgs(args, func(n int, pointer interface{}) error {
+		rv[n] = pointer
+		return nil
+	})
+	return rv
+}
+
+// StructArgSchemaTargets returns the arguments of kind struct or pointer-to-struct; these are
+// the arguments a request body is decoded into and therefore the targets of schema validation.
+// Arguments removed by PruneIn are not returned.
+func (f *Func) StructArgSchemaTargets() []Arg {
+	var rv []Arg
+	for _, arg := range f.AllArgs() {
+		T := arg.T
+		if T.Kind() == reflect.Ptr {
+			T = T.Elem()
+		}
+		if T.Kind() == reflect.Struct {
+			rv = append(rv, arg)
+		}
+	}
+	return rv
+}
+
+// ValidateJSON calls schema with data and, if schema returns nil, unmarshals data into
+// Pointers[index] with encoding/json; schema is typically an adapter around a JSON schema
+// package and the argument is typically one returned by StructArgSchemaTargets.
+//
+// Errors from schema or from unmarshaling are wrapped in an *ArgError; the argument is not
+// modified if schema returns an error.  An error wrapping ErrNotFound is returned if the
+// argument does not have an entry in Pointers.
+func (args *Args) ValidateJSON(index int, schema func([]byte) error, data []byte) error {
+	p, err := args.pointer(index)
+	if err != nil {
+		return err
+	} else if err = schema(data); err != nil {
+		return &ArgError{N: index, Err: err}
+	} else if err = json.Unmarshal(data, p); err != nil {
+		return &ArgError{N: index, Err: err}
+	}
+	return nil
+}
+
+// ValidateArgs calls validate with the pointer of each struct argument in args; use it after
+// populating args and before calling Call().
+//
+// validate is typically an adapter around a validation package that inspects struct tags,
+// for example:
+//
+//	v := validator.New()
+//	err := f.ValidateArgs(args, v.Struct)
+//
+// Every struct argument is validated.  Each error returned by validate is wrapped in an *ArgError
+// and the errors are returned together as Errors; nil is returned if there are no errors.
+// Arguments without an entry in args.Pointers, such as pruned arguments, are skipped.
+func (f *Func) ValidateArgs(args *Args, validate func(v interface{}) error) error {
+	var errs Errors
+	_ = f.structArgs(args, func(n int, pointer interface{}) error {
+		if err := validate(pointer); err != nil {
+			errs = append(errs, &ArgError{N: n, Err: err})
+		}
+		return nil
+	})
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
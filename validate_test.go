@@ -0,0 +1,121 @@
+package call_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_ValidateArgs() {
+	type Login struct {
+		Username string `validate:"required"`
+	}
+	fn := func(login Login, req examples.Request) {}
+
+	// A stand-in for a real validation package.
+	validate := func(v interface{}) error {
+		if login, ok := v.(*Login); ok && login.Username == "" {
+			return fmt.Errorf("username is required")
+		}
+		return nil
+	}
+
+	f := call.StatFunc(fn)
+	args := f.Args()
+	if err := f.ValidateArgs(args, validate); err != nil {
+		fmt.Println(err)
+	}
+	args.Pointers[0].(*Login).Username = "bob"
+	if err := f.ValidateArgs(args, validate); err == nil {
+		f.Call(args)
+		fmt.Println("valid")
+	}
+
+	// Output: argument 0: username is required
+	// valid
+}
+
+func TestFunc_ValidateArgs(t *testing.T) {
+	chk := assert.New(t)
+	//
+	sentinel := errors.New("sentinel")
+	var talk examples.HTTP
+	m, _ := call.Stat(talk).Methods.Named("Handler")
+	args := m.Args()
+	var validated []interface{}
+	err := m.ValidateArgs(args, func(v interface{}) error {
+		validated = append(validated, v)
+		return sentinel
+	})
+	// Only the inline form struct is validated; the receiver has no pointer.
+	chk.Equal([]interface{}{args.Pointers[4]}, validated)
+	chk.ErrorIs(err, sentinel)
+	var argErr *call.ArgError
+	if chk.True(errors.As(err, &argErr)) {
+		chk.Equal(4, argErr.N)
+	}
+	chk.EqualError(err, "argument 4: sentinel")
+	m.Call(args)
+	//
+	f := call.StatFunc(func(a, b examples.Request, n int) {})
+	args = f.Args()
+	err = f.ValidateArgs(args, func(v interface{}) error { return sentinel })
+	chk.EqualError(err, "argument 0: sentinel; argument 1: sentinel")
+	chk.False(errors.Is(err, call.ErrNotFound))
+	chk.NoError(f.ValidateArgs(args, func(v interface{}) error { return nil }))
+	f.Call(args)
+}
+
+func TestFunc_StructArgPointers(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Body struct{ N int }
+	f := call.StatFunc(func(Body, int, *Body, examples.Session, Body) {})
+	args := f.Args()
+	pointers := f.StructArgPointers(args)
+	chk.Len(pointers, 2)
+	chk.True(pointers[0] == args.Pointers[0])
+	chk.True(pointers[4] == args.Pointers[4])
+	//
+	args.Pointers[4] = nil
+	chk.Len(f.StructArgPointers(args), 1)
+}
+
+func TestArgs_ValidateJSON(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Login struct {
+		Username string `json:"username"`
+	}
+	f := call.StatFunc(func(n int, login *Login, form Login) string { return login.Username + form.Username })
+	targets := f.StructArgSchemaTargets()
+	if chk.Len(targets, 2) {
+		chk.Equal(1, targets[0].N)
+		chk.Equal(2, targets[1].N)
+	}
+	// A stand-in for a JSON schema package.
+	errSchema := errors.New("schema")
+	schema := func(data []byte) error {
+		if len(data) < 3 {
+			return errSchema
+		}
+		return nil
+	}
+	args := f.Args()
+	var argErr *call.ArgError
+	err := args.ValidateJSON(2, schema, []byte(`{}`))
+	chk.ErrorIs(err, errSchema)
+	if chk.ErrorAs(err, &argErr) {
+		chk.Equal(2, argErr.N)
+	}
+	chk.Error(args.ValidateJSON(2, schema, []byte(`{"username":1}`)))
+	chk.NoError(args.ValidateJSON(1, schema, []byte(`{"username":"a"}`)))
+	chk.NoError(args.ValidateJSON(2, schema, []byte(`{"username":"b"}`)))
+	chk.ErrorIs(args.ValidateJSON(3, schema, nil), call.ErrNotFound)
+	chk.Equal([]interface{}{"ab"}, f.Call(args).Values)
+}
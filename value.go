@@ -0,0 +1,144 @@
+package call
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// nilable returns true if the zero value of kind K is nil.
+func nilable(K reflect.Kind) bool {
+	switch K {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return true
+	}
+	return false
+}
+
+// valueOf returns v as a reflect.Value that can be passed as an argument of type T; see adapt.
+//
+// A nil v is converted to the zero value of T if T is a nilable kind.  An error wrapping
+// ErrIncompatible is returned if v can not be assigned to T.
+func valueOf(v interface{}, T reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		if !nilable(T.Kind()) {
+			return zeroReflectValue, fmt.Errorf("%w: nil is not assignable to %v", ErrIncompatible, T)
+		}
+		return reflect.Zero(T), nil
+	}
+	return adapt(reflect.ValueOf(v), T)
+}
+
+// adapt returns V as a reflect.Value that can be passed as an argument of type T.
+//
+// If V is not assignable to T then pointers and values are adapted: a *X is dereferenced
+// when X is assignable to T and an addressable X has its address taken when *X is
+// assignable to T.  An error wrapping ErrIncompatible is returned if V is a nil pointer that
+// must be dereferenced, V must be addressed but is not addressable, or V can not be assigned
+// to T.
+func adapt(V reflect.Value, T reflect.Type) (reflect.Value, error) {
+	VT := V.Type()
+	switch {
+	case VT.AssignableTo(T):
+		return V, nil
+	case VT.Kind() == reflect.Ptr && VT.Elem().AssignableTo(T):
+		if V.IsNil() {
+			return zeroReflectValue, fmt.Errorf("%w: nil %v can not be dereferenced for %v", ErrIncompatible, VT, T)
+		}
+		return V.Elem(), nil
+	case reflect.PtrTo(VT).AssignableTo(T):
+		if !V.CanAddr() {
+			return zeroReflectValue, fmt.Errorf("%w: %v is not addressable for %v", ErrIncompatible, VT, T)
+		}
+		return V.Addr(), nil
+	}
+	return zeroReflectValue, fmt.Errorf("%w: %v is not assignable to %v", ErrIncompatible, VT, T)
+}
+
+// spreadOf returns the slice v as a reflect.Value of sliceType suitable for the variadic
+// parameter of reflect.Value.CallSlice.
+//
+// If v is already a sliceType it is returned as-is; otherwise a new slice is created and each
+// element is assigned individually.  Elements that are interfaces are checked by their dynamic
+// values so that a []interface{} can be spread into any variadic parameter.
+func spreadOf(v interface{}, sliceType reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		return reflect.Zero(sliceType), nil
+	}
+	V := reflect.ValueOf(v)
+	if V.Type() == sliceType {
+		return V, nil
+	} else if V.Kind() != reflect.Slice && V.Kind() != reflect.Array {
+		return zeroReflectValue, fmt.Errorf("%w: %v is not a slice", ErrIncompatible, V.Type())
+	}
+	elemType := sliceType.Elem()
+	rv := reflect.MakeSlice(sliceType, V.Len(), V.Len())
+	for k, max := 0, V.Len(); k < max; k++ {
+		E := V.Index(k)
+		if E.Kind() == reflect.Interface && !E.Type().AssignableTo(elemType) {
+			if E.IsNil() {
+				if !nilable(elemType.Kind()) {
+					return zeroReflectValue, fmt.Errorf("%w: element %v: nil is not assignable to %v", ErrIncompatible, k, elemType)
+				}
+				continue
+			}
+			E = E.Elem()
+		}
+		if !E.Type().AssignableTo(elemType) {
+			return zeroReflectValue, fmt.Errorf("%w: element %v: %v is not assignable to %v", ErrIncompatible, k, E.Type(), elemType)
+		}
+		rv.Index(k).Set(E)
+	}
+	return rv, nil
+}
+
+// sameValue returns true if A and B hold the same value of the same type.  Interfaces are
+// compared by their dynamic values; values of reference kinds, such as pointers and maps, are
+// the same if they refer to the same data; arrays and structs are compared element by element.
+//
+// Unlike comparing with == sameValue never panics for values that are not comparable.
+func sameValue(A, B reflect.Value) bool {
+	if A.Kind() == reflect.Interface {
+		A = A.Elem()
+	}
+	if B.Kind() == reflect.Interface {
+		B = B.Elem()
+	}
+	if !A.IsValid() || !B.IsValid() {
+		return A.IsValid() == B.IsValid()
+	} else if A.Type() != B.Type() {
+		return false
+	}
+	switch A.Kind() {
+	case reflect.Bool:
+		return A.Bool() == B.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return A.Int() == B.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return A.Uint() == B.Uint()
+	case reflect.Float32, reflect.Float64:
+		return A.Float() == B.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return A.Complex() == B.Complex()
+	case reflect.String:
+		return A.String() == B.String()
+	case reflect.Chan, reflect.Func, reflect.Map, reflect.Ptr, reflect.UnsafePointer:
+		return A.Pointer() == B.Pointer()
+	case reflect.Slice:
+		return A.Pointer() == B.Pointer() && A.Len() == B.Len()
+	case reflect.Array:
+		for k, max := 0, A.Len(); k < max; k++ {
+			if !sameValue(A.Index(k), B.Index(k)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		for k, max := 0, A.NumField(); k < max; k++ {
+			if !sameValue(A.Field(k), B.Field(k)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
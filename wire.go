@@ -0,0 +1,131 @@
+package call
+
+import (
+	"reflect"
+)
+
+// ArgStatus describes how an argument in an *Args was wired; see Func.WireReport.
+type ArgStatus int
+
+const (
+	// ArgCreated is an argument created by Args() with an entry in Pointers.
+	ArgCreated ArgStatus = iota
+	// ArgCached is an argument whose value is still the shared value from InCache.
+	ArgCached
+	// ArgSuppliedByCaller is an argument whose value was assigned by the caller.
+	ArgSuppliedByCaller
+	// ArgPruned is an argument removed by PruneIn that was not supplied.
+	ArgPruned
+	// ArgMissing is an argument without a value that was not removed by PruneIn, such as an
+	// argument skipped by ArgsIf.
+	ArgMissing
+	// ArgNilInterface is an interface argument whose value is the nil interface.
+	ArgNilInterface
+	// ArgMismatched is an argument whose value is not assignable to the argument type.
+	ArgMismatched
+	// ArgProvided is an argument without an entry in Pointers that has a named provider or
+	// an interface binding; see SetNamedProvider and BindInterface.
+	ArgProvided
+)
+
+// String returns the name of the ArgStatus.
+func (s ArgStatus) String() string {
+	switch s {
+	case ArgCreated:
+		return "Created"
+	case ArgCached:
+		return "Cached"
+	case ArgSuppliedByCaller:
+		return "SuppliedByCaller"
+	case ArgPruned:
+		return "Pruned"
+	case ArgMissing:
+		return "Missing"
+	case ArgNilInterface:
+		return "NilInterface"
+	case ArgMismatched:
+		return "Mismatched"
+	case ArgProvided:
+		return "Provided"
+	}
+	return "Unknown"
+}
+
+// MarshalText returns String(); it allows an ArgStatus to be serialized by name.
+func (s ArgStatus) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ArgReport is the status of a single argument in a WireReport.
+type ArgReport struct {
+	// N is the argument index.
+	N int
+	// Type is the argument type as a string.
+	Type string
+	// Status is how the argument was wired.
+	Status ArgStatus
+}
+
+// WireReport is a structured diagnostic of the arguments in an *Args; see Func.WireReport.
+type WireReport struct {
+	// Args has one entry per argument of the function in argument order.
+	Args []ArgReport
+}
+
+// OK returns true if no argument is ArgPruned, ArgMissing, ArgNilInterface, or ArgMismatched.
+func (r WireReport) OK() bool {
+	for _, arg := range r.Args {
+		switch arg.Status {
+		case ArgPruned, ArgMissing, ArgNilInterface, ArgMismatched:
+			return false
+		}
+	}
+	return true
+}
+
+// WireReport returns the status of every argument in args; a dispatcher can call it before
+// Call() and reject a request whose report is not OK rather than letting the call panic.
+// The report contains only strings and integers and can be serialized.
+//
+// For a Method the receiver is reported as ArgSuppliedByCaller.  A missing receiver, or a
+// missing argument that is in neither InCreate nor InCache, is reported as ArgPruned.  An
+// argument with a provider is reported as ArgProvided even if the caller replaced its value.
+func (f *Func) WireReport(args *Args) WireReport {
+	rv := WireReport{Args: make([]ArgReport, f.NumIn)}
+	known := map[int]Arg{}
+	for _, arg := range f.AllArgs() {
+		known[arg.N] = arg
+	}
+	cached := map[int]bool{}
+	for _, arg := range f.InCache {
+		cached[arg.N] = true
+	}
+	for n, T := range f.InTypes {
+		report := ArgReport{N: n, Type: T.String()}
+		var V reflect.Value
+		if n < len(args.Values) {
+			V = args.Values[n]
+		}
+		arg, ok := known[n]
+		switch {
+		case !V.IsValid() && !ok:
+			report.Status = ArgPruned
+		case !V.IsValid():
+			report.Status = ArgMissing
+		case !V.Type().AssignableTo(T):
+			report.Status = ArgMismatched
+		case T.Kind() == reflect.Interface && V.Kind() == reflect.Interface && V.IsNil():
+			report.Status = ArgNilInterface
+		case cached[n] && sameValue(V, arg.V):
+			report.Status = ArgCached
+		case n < len(args.Pointers) && args.Pointers[n] != nil:
+			report.Status = ArgCreated
+		case f.provider(n) != nil || f.bindings[n] != nil:
+			report.Status = ArgProvided
+		default:
+			report.Status = ArgSuppliedByCaller
+		}
+		rv.Args[n] = report
+	}
+	return rv
+}
@@ -0,0 +1,87 @@
+package call_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_WireReport() {
+	fn := func(n int, sess examples.Session, s string) {}
+	f := call.StatFunc(fn)
+	f.PruneIn(reflect.TypeOf(""))
+	report := f.WireReport(f.Args())
+	for _, arg := range report.Args {
+		fmt.Println(arg.N, arg.Type, arg.Status)
+	}
+	fmt.Println(report.OK())
+
+	// Output: 0 int Created
+	// 1 examples.Session NilInterface
+	// 2 string Pruned
+	// false
+}
+
+func TestFunc_WireReport(t *testing.T) {
+	chk := assert.New(t)
+	//
+	type Options struct{ Limit int }
+	f := call.StatFunc(func(n int, opts Options, sess examples.Session, s string, req *examples.Request) {})
+	f.PruneIn(reflect.TypeOf(""))
+	chk.NoError(f.MarkImmutable(1, reflect.ValueOf(Options{Limit: 1})))
+	args := f.ArgsIf(func(arg call.Arg) bool { return arg.N != 4 })
+	statuses := func(report call.WireReport) []call.ArgStatus {
+		var rv []call.ArgStatus
+		for _, arg := range report.Args {
+			rv = append(rv, arg.Status)
+		}
+		return rv
+	}
+	chk.Equal([]call.ArgStatus{call.ArgCreated, call.ArgCached, call.ArgNilInterface, call.ArgPruned, call.ArgMissing}, statuses(f.WireReport(args)))
+	//
+	args.Values[0] = reflect.ValueOf("not an int")
+	chk.NoError(f.Set(args, 2, examples.MapSession{}))
+	chk.NoError(f.Set(args, 3, "s"))
+	chk.NoError(f.SetValue(args, 4, reflect.ValueOf(&examples.Request{})))
+	report := f.WireReport(args)
+	chk.Equal([]call.ArgStatus{call.ArgMismatched, call.ArgCached, call.ArgSuppliedByCaller, call.ArgSuppliedByCaller, call.ArgSuppliedByCaller}, statuses(report))
+	chk.False(report.OK())
+	args.Values[0] = reflect.ValueOf(1)
+	chk.True(f.WireReport(args).OK())
+	// Cached values are compared by value.
+	args.Values[1] = reflect.ValueOf(Options{Limit: 1})
+	chk.Equal(call.ArgCached, f.WireReport(args).Args[1].Status)
+	args.Values[1] = reflect.ValueOf(Options{Limit: 2})
+	chk.Equal(call.ArgSuppliedByCaller, f.WireReport(args).Args[1].Status)
+	sess := examples.MapSession{}
+	chk.NoError(f.SetDefault(2, examples.Session(sess)))
+	chk.NoError(f.Set(args, 2, sess))
+	chk.Equal(call.ArgCached, f.WireReport(args).Args[2].Status)
+	chk.NoError(f.Set(args, 2, examples.MapSession{}))
+	chk.Equal(call.ArgSuppliedByCaller, f.WireReport(args).Args[2].Status)
+	f.Call(args)
+	// Reports are serializable.
+	b, err := json.Marshal(call.WireReport{Args: []call.ArgReport{{N: 1, Type: "int", Status: call.ArgMissing}}})
+	chk.NoError(err)
+	chk.Equal(`{"Args":[{"N":1,"Type":"int","Status":"Missing"}]}`, string(b))
+	// Methods report the receiver as supplied.
+	m, err := call.Stat(joiner{}).Methods.Named("Pair")
+	chk.NoError(err)
+	chk.Equal([]call.ArgStatus{call.ArgSuppliedByCaller, call.ArgCreated, call.ArgCreated}, statuses(m.WireReport(m.Args())))
+	// Arguments from a named provider or an interface binding are reported as provided.
+	f = call.StatFunc(func(opts Options, sess examples.Session) {})
+	chk.NoError(f.MarkImmutable(0, reflect.ValueOf(Options{})))
+	chk.NoError(f.SetArgName(0, "opts"))
+	chk.NoError(f.SetNamedProvider("opts", func() reflect.Value { return reflect.ValueOf(Options{Limit: 5}) }))
+	chk.NoError(f.BindInterface(reflect.TypeOf((*examples.Session)(nil)).Elem(), func() reflect.Value {
+		return reflect.ValueOf(examples.MapSession{})
+	}))
+	chk.Equal([]call.ArgStatus{call.ArgProvided, call.ArgProvided}, statuses(f.WireReport(f.ArgsValues())))
+	chk.Equal("Provided", call.ArgProvided.String())
+}
@@ -0,0 +1,79 @@
+package call
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Encoder writes a single return value v to w.
+type Encoder func(w io.Writer, v interface{}) error
+
+// EncodeJSON is an Encoder that writes v as JSON followed by a newline.
+func EncodeJSON(w io.Writer, v interface{}) error {
+	return json.NewEncoder(w).Encode(v)
+}
+
+// EncodeText is an Encoder that writes v using the %v verb followed by a newline.
+func EncodeText(w io.Writer, v interface{}) error {
+	_, err := fmt.Fprintf(w, "%v\n", v)
+	return err
+}
+
+// EncodeAuto is an Encoder that writes scalar values (bools, numbers, and strings) with
+// EncodeText and all other values with EncodeJSON.  EncodeAuto is the default Encoder
+// used by CallAndWrite.
+func EncodeAuto(w io.Writer, v interface{}) error {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return EncodeText(w, v)
+	}
+	return EncodeJSON(w, v)
+}
+
+// writeOptions are the options accepted by CallAndWrite.
+type writeOptions struct {
+	encoder Encoder
+}
+
+// WriteOption configures the behavior of CallAndWrite.
+type WriteOption func(*writeOptions)
+
+// WithEncoder sets the Encoder used by CallAndWrite to write return values.
+func WithEncoder(enc Encoder) WriteOption {
+	return func(o *writeOptions) {
+		o.encoder = enc
+	}
+}
+
+// CallAndWrite invokes the function via Call() and writes each returned value to w
+// using the configured Encoder; by default EncodeAuto is used.
+//
+// Error values and nil values are not written to w.  If the function returns an error
+// then it is returned from CallAndWrite after all other values have been written.  If
+// writing fails the write error is returned instead.
+//
+// As with Call() the args are returned to the argument pool.
+func (f *Func) CallAndWrite(args *Args, w io.Writer, opts ...WriteOption) error {
+	o := writeOptions{
+		encoder: EncodeAuto,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	//
+	result := f.Call(args)
+	for _, v := range result.Values {
+		if _, ok := v.(error); ok || v == nil {
+			continue
+		}
+		if err := o.encoder(w, v); err != nil {
+			return err
+		}
+	}
+	return result.Error
+}
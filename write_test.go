@@ -0,0 +1,67 @@
+package call_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nofeaturesonlybugs/call"
+	"github.com/nofeaturesonlybugs/call/examples"
+)
+
+func ExampleFunc_CallAndWrite() {
+	fn := func(num int) (string, map[string]int, error) {
+		return "Hi!", map[string]int{"num": num}, nil
+	}
+
+	f := call.StatFunc(fn)
+	args := f.Args()
+	*(args.Pointers[0].(*int)) = 42
+	if err := f.CallAndWrite(args, os.Stdout); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output: Hi!
+	// {"num":42}
+}
+
+func ExampleFunc_CallAndWrite_encoder() {
+	var talk examples.Talker
+	instance := call.Stat(talk)
+	m, _ := instance.Methods.Named("Error") // error ignored for brevity
+	// Methods embed *Func and can also CallAndWrite; the method's error is returned.
+	err := m.CallAndWrite(m.Args(), os.Stdout, call.WithEncoder(call.EncodeJSON))
+	fmt.Println(err)
+
+	fn := func() []string {
+		return []string{"a", "b"}
+	}
+	f := call.StatFunc(fn)
+	_ = f.CallAndWrite(f.Args(), os.Stdout, call.WithEncoder(call.EncodeText))
+
+	// Output: examples.Talker made an error
+	// [a b]
+}
+
+func TestFunc_CallAndWrite_EncoderError(t *testing.T) {
+	chk := assert.New(t)
+	//
+	fn := func() (int, error) {
+		return 42, fmt.Errorf("handler")
+	}
+	encoder := func(w io.Writer, v interface{}) error {
+		return fmt.Errorf("encoder")
+	}
+	var buf bytes.Buffer
+	f := call.StatFunc(fn)
+	err := f.CallAndWrite(f.Args(), &buf, call.WithEncoder(encoder))
+	chk.EqualError(err, "encoder")
+	//
+	err = f.CallAndWrite(f.Args(), &buf)
+	chk.EqualError(err, "handler")
+	chk.Equal("42\n", buf.String())
+}